@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"example.com/advent2022/days"
+)
+
+const cliUsage = `Usage: advent2022 run [--year YYYY] [--day N] [--part A|B] [--inputs dir] [--input path] [--timeout 30s] [--accept] [--render out.png]
+
+Solves puzzles without opening a window. Each part's example tests are run
+first and the puzzle is only solved if they all pass. With no --year every
+event is run, with no --day every day of the event, and with no --part both
+parts. --day needs --year unless there's only one event. Puzzle inputs are
+read from dir/YYYY/dayN.txt, days without one only run their tests. --timeout
+limits how long each day (tests and both parts together) is allowed to run.
+
+Solutions to the puzzle inputs are checked against the answers recorded in
+answers/YYYY.json under the config directory (or --answers dir), with a
+warning for any event that has no answers file. With --accept, any solution that isn't recorded yet (or
+doesn't match) is shown and recorded once you confirm it's right.
+
+--render saves the visualization of each solution to a .png, .svg or .pdf
+file. When more than one part is run, the year, day and part are added to
+the file name, ex: out-2022-day14B.png.
+`
+
+// Entry point for "advent2022 run ...", returns the process exit code
+func runCLI(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), cliUsage)
+		flags.PrintDefaults()
+	}
+	year := flags.Int("year", 0, "event to solve, 0 for every event")
+	dayNum := flags.Int("day", 0, "day to solve, 0 for every day")
+	partStr := flags.String("part", "", "part to solve (A or B), empty for both")
+	inputDir := flags.String("inputs", days.DefaultInputDir(), "directory holding the puzzle inputs")
+	inputPath := flags.String("input", "", "file to use instead of the day's puzzle input (requires --day)")
+	timeout := flags.Duration("timeout", 0, "time allowed for each day, 0 for no limit")
+	answersDir := flags.String("answers", days.DefaultAnswersDir(), "directory holding the recorded answers")
+	accept := flags.Bool("accept", false, "ask to record solutions that don't match the recorded answers")
+	render := flags.String("render", "", "file to save each solution's visualization to (.png, .svg or .pdf)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "unexpected arguments: "+strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return 2
+	}
+
+	parts := []days.Part{days.PartA, days.PartB}
+	if *partStr != "" {
+		p, err := days.ParsePart(*partStr)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		parts = []days.Part{p}
+	}
+
+	if *dayNum != 0 && *year == 0 {
+		years := days.Years()
+		if len(years) != 1 {
+			fmt.Fprintln(os.Stderr, "--day requires --year when there's more than one event")
+			return 2
+		}
+		*year = years[0]
+	}
+	var selected []days.Day
+	switch {
+	case *dayNum != 0:
+		d, ok := days.Lookup(*year, *dayNum)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "no solver for %d day %d\n", *year, *dayNum)
+			return 2
+		}
+		selected = []days.Day{d}
+	case *year != 0:
+		selected = days.InYear(*year)
+		if len(selected) == 0 {
+			fmt.Fprintln(os.Stderr, "no solvers for", *year)
+			return 2
+		}
+	default:
+		selected = days.All()
+	}
+
+	if *render != "" {
+		if _, err := exportFormat(*render); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+
+	known, err := loadAnswers(*answersDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to read answers:", err)
+		return 2
+	}
+	answers := &cliAnswers{known: known, dir: *answersDir, accept: *accept, stdin: bufio.NewReader(os.Stdin)}
+
+	loadInput := func(d days.Day) (string, error) {
+		return d.LoadPuzzleInput(*inputDir)
+	}
+	if *inputPath != "" {
+		if *dayNum == 0 {
+			fmt.Fprintln(os.Stderr, "--input requires --day")
+			return 2
+		}
+		if *accept {
+			fmt.Fprintln(os.Stderr, "--accept can't be used with --input, answers are only recorded for puzzle inputs")
+			return 2
+		}
+		// Recorded answers are for the puzzle inputs, not this one
+		answers = nil
+		b, err := os.ReadFile(*inputPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "failed to read input:", err)
+			return 2
+		}
+		loadInput = func(days.Day) (string, error) {
+			return days.NormalizeInput(string(b)), nil
+		}
+	}
+
+	// Day numbers repeat across events, so each event gets a heading
+	headings := len(selected) > 0 && selected[0].Year != selected[len(selected)-1].Year
+	failed := false
+	for i, d := range selected {
+		if headings && (i == 0 || selected[i-1].Year != d.Year) {
+			fmt.Printf("== Advent of Code %d ==\n", d.Year)
+		}
+		input, inputErr := loadInput(d)
+		if inputErr != nil && !errors.Is(inputErr, days.ErrInputMissing) {
+			fmt.Printf("Day %d: failed to read puzzle input: %s\n", d.Number, inputErr)
+			failed = true
+			continue
+		}
+		ctx, cancel := context.Background(), context.CancelFunc(func() {})
+		if *timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+		}
+		for _, p := range parts {
+			renderTo := *render
+			if renderTo != "" && len(selected)*len(parts) > 1 {
+				renderTo = renderPath(renderTo, d, p)
+			}
+			if !runPartCLI(ctx, d, p, input, inputErr, answers, renderTo) {
+				failed = true
+			}
+		}
+		cancel()
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// Runs the tests and then the puzzle input for a part, returns false if
+// anything failed. A missing puzzle input (inputErr) only skips the solve.
+// The solution is checked against answers unless it's nil, and its
+// visualization is saved to render unless that's empty.
+func runPartCLI(ctx context.Context, d days.Day, p days.Part, input string, inputErr error, answers *cliAnswers, render string) bool {
+	if !d.Implemented(p) {
+		fmt.Printf("Day %d part %s: not implemented\n", d.Number, p)
+		return true
+	}
+
+	// Status line has to be cleared before printing anything else
+	clear := func() {}
+	if isTerminal(os.Stderr) {
+		prefix := fmt.Sprintf("Day %d part %s: ", d.Number, p)
+		ctx = days.WithProgress(ctx, throttleProgress(func(pr days.Progress) {
+			printStatus(prefix, pr)
+		}, 100*time.Millisecond))
+		clear = clearStatus
+	}
+
+	results := runTests(ctx, d, p)
+	clear()
+	for _, r := range results {
+		if r.passed() {
+			fmt.Printf("Day %d part %s: test %d passed\n", d.Number, p, r.index)
+		} else {
+			fmt.Println(r.failure(d, p))
+			fmt.Fprint(os.Stderr, panicStack(r.err))
+		}
+	}
+	if !allPassed(results) {
+		fmt.Printf("Day %d part %s: skipping puzzle input because tests failed\n", d.Number, p)
+		return false
+	}
+
+	if inputErr != nil {
+		fmt.Printf("Day %d part %s: skipping puzzle input: %s\n", d.Number, p, inputErr)
+		return true
+	}
+	res, stats, err := measureSolve(ctx, d, p, input, nil)
+	clear()
+	if err != nil {
+		fmt.Printf("Day %d part %s solution returned err: %s\n", d.Number, p, err)
+		fmt.Fprint(os.Stderr, panicStack(err))
+		return false
+	}
+	ok := true
+	if answers == nil {
+		fmt.Printf("Day %d part %s solution is: %s\n", d.Number, p, cliAnswer(res))
+	} else {
+		ok = answers.check(d, p, res)
+	}
+	for _, f := range res.Facts {
+		fmt.Printf("Day %d part %s: %s: %s\n", d.Number, p, f.Name, f.Value)
+	}
+	if len(res.Artifacts) > 0 {
+		names := make([]string, len(res.Artifacts))
+		for i, a := range res.Artifacts {
+			names[i] = a.Name
+		}
+		fmt.Printf("Day %d part %s artifacts: %s\n", d.Number, p, strings.Join(names, ", "))
+	}
+	fmt.Printf("Day %d part %s took: %s, %s\n", d.Number, p, formatTimings(res), stats)
+	if render != "" {
+		// The day's drawing code runs here rather than in Solve, so it needs
+		// its own guard against panics
+		v, err := d.BuildVisual(p, res.Visual)
+		if err == nil && v != nil {
+			if panicErr := d.Guard(p, func() { err = saveVisual(render, v) }); panicErr != nil {
+				err = panicErr
+			}
+		}
+		if err != nil {
+			fmt.Printf("Day %d part %s: failed to save visualization: %s\n", d.Number, p, err)
+			fmt.Fprint(os.Stderr, panicStack(err))
+			ok = false
+		} else if v == nil {
+			fmt.Printf("Day %d part %s: no visualization to save\n", d.Number, p)
+		} else {
+			fmt.Printf("Day %d part %s: saved visualization to %s\n", d.Number, p, render)
+		}
+	}
+	return ok
+}
+
+// File for a part's visualization when several parts are saved, ex:
+// out.png -> out-2022-day14B.png
+func renderPath(path string, d days.Day, p days.Part) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d-day%d%s%s", strings.TrimSuffix(path, ext), d.Year, d.Number, p, ext)
+}
+
+// Answers drawn as pictures span several lines, so they start on their own line
+func cliAnswer(res days.Result) string {
+	if res.Kind == days.ImageOnlyAnswer {
+		return "\n" + res.Answer
+	}
+	return res.Answer
+}
+
+type cliAnswers struct {
+	known  days.Answers
+	dir    string
+	accept bool
+	stdin  *bufio.Reader
+}
+
+// Prints the solution along with how it compares to the recorded answer,
+// returns false if they don't match. In accept mode new answers are recorded
+// once confirmed.
+func (a *cliAnswers) check(d days.Day, p days.Part, res days.Result) bool {
+	solution := res.Answer
+	recorded, ok := a.known.Get(d, p)
+	switch {
+	case ok && recorded == solution:
+		fmt.Printf("Day %d part %s solution is: %s (matches recorded answer)\n", d.Number, p, cliAnswer(res))
+		return true
+	case ok:
+		fmt.Printf("Day %d part %s solution is: %s, but the recorded answer is: %s\n", d.Number, p, cliAnswer(res), recorded)
+	default:
+		fmt.Printf("Day %d part %s solution is: %s (no recorded answer)\n", d.Number, p, cliAnswer(res))
+	}
+	if !a.accept {
+		return !ok
+	}
+
+	if !a.confirm(fmt.Sprintf("Record %s as the answer for %s part %s? [y/N] ", solution, d.Key(), p)) {
+		return !ok
+	}
+	a.known.Set(d, p, solution)
+	// Saved straight away so answers aren't lost if a later day is interrupted
+	if err := a.known.Save(a.dir, d.Year); err != nil {
+		fmt.Printf("Day %d part %s: failed to record answer: %s\n", d.Number, p, err)
+		return false
+	}
+	fmt.Printf("Day %d part %s: recorded answer in %s\n", d.Number, p, days.AnswersPath(a.dir, d.Year))
+	return true
+}
+
+// Asks a yes/no question on stdin, anything but "y" or "yes" is a no
+func (a *cliAnswers) confirm(question string) bool {
+	fmt.Print(question)
+	reply, err := a.stdin.ReadString('\n')
+	if err != nil && reply == "" {
+		fmt.Println()
+		return false
+	}
+	reply = strings.ToLower(strings.TrimSpace(reply))
+	return reply == "y" || reply == "yes"
+}
+
+// Only draw status lines when someone is watching, they'd just clutter logs
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Overwrites the current stderr line with the progress
+func printStatus(prefix string, pr days.Progress) {
+	status := prefix
+	if pr.Fraction >= 0 {
+		status += fmt.Sprintf("%3.0f%% ", pr.Fraction*100)
+	}
+	status += formatCounters(pr.Counters)
+	fmt.Fprint(os.Stderr, "\r\033[K"+status)
+}
+
+func clearStatus() {
+	fmt.Fprint(os.Stderr, "\r\033[K")
+}
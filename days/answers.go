@@ -0,0 +1,88 @@
+package days
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DefaultAnswersDir is where recorded answers live unless told otherwise:
+// $XDG_CONFIG_HOME/advent2022/answers (or the OS equivalent) next to the
+// inputs, falling back to ./answers if there's no config directory. Each event
+// has its own file, ex: answers/2022.json
+func DefaultAnswersDir() string {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "answers"
+	}
+	return filepath.Join(config, "advent2022", "answers")
+}
+
+// Answers are confirmed answers to the real puzzle inputs, keyed by year, day
+// number then part. Kept so refactors can't quietly change a solution that's
+// known to be right.
+type Answers map[int]map[int]map[Part]string
+
+// Reads the answers file of every registered year in dir. Years without a file
+// are listed in missing rather than being an error, their solutions just can't
+// be checked.
+func LoadAnswers(dir string) (answers Answers, missing []int, err error) {
+	answers = Answers{}
+	for _, year := range Years() {
+		path := AnswersPath(dir, year)
+		b, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			missing = append(missing, year)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		inYear := make(map[int]map[Part]string)
+		if err := json.Unmarshal(b, &inYear); err != nil {
+			return nil, nil, errors.New("failed to parse " + path + ": " + err.Error())
+		}
+		answers[year] = inYear
+	}
+	return answers, missing, nil
+}
+
+// Path of the file holding the year's answers within dir
+func AnswersPath(dir string, year int) string {
+	return filepath.Join(dir, strconv.Itoa(year)+".json")
+}
+
+// Writes the year's answers to its file in dir
+func (a Answers) Save(dir string, year int) error {
+	inYear := a[year]
+	if inYear == nil {
+		inYear = make(map[int]map[Part]string)
+	}
+	b, err := json.MarshalIndent(inYear, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(AnswersPath(dir, year), append(b, '\n'), 0o644)
+}
+
+// Recorded answer for the part, ok is false if there isn't one
+func (a Answers) Get(d Day, p Part) (answer string, ok bool) {
+	answer, ok = a[d.Year][d.Number][p]
+	return answer, ok
+}
+
+func (a Answers) Set(d Day, p Part, answer string) {
+	if a[d.Year] == nil {
+		a[d.Year] = make(map[int]map[Part]string)
+	}
+	if a[d.Year][d.Number] == nil {
+		a[d.Year][d.Number] = make(map[Part]string)
+	}
+	a[d.Year][d.Number][p] = answer
+}
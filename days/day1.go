@@ -1,34 +1,43 @@
 package days
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"image/color"
 	"sort"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      1,
+		Title:       "Calorie Counting",
+		PartATests:  day1TestsPartA,
+		PartBTests:  day1TestsPartB,
+		PartAPrompt: "Find the Elf carrying the most Calories. How many total Calories is that Elf carrying?",
+		PartBPrompt: "Find the top three Elves carrying the most Calories. How many Calories are those Elves carrying in total?",
+		Solver:      Day1Solver{},
+	})
+}
+
 func calculateCalorieCounts(input string) (plotter.Values, error) {
 	lines := strings.Split(input, "\n")
 	// TODO: precalculate size
 	calories := make(plotter.Values, 10)
 	current_cal := 0.0
-	for _, line := range lines {
+	for i, line := range lines {
 		if line == "" {
 			calories = append(calories, current_cal)
 			current_cal = 0.0
 		} else {
 			new_cal, err := strconv.ParseFloat(line, 64)
 			if err != nil {
-				failMsg := fmt.Sprint("Failed to parse line into float: ", line, ", err: ", err)
-				fmt.Println(failMsg)
-				return nil, errors.New(failMsg)
+				return nil, lineError(i, line, "expected a calorie count").wrap(err)
 			}
 			current_cal += new_cal
 		}
@@ -40,7 +49,9 @@ func calculateCalorieCounts(input string) (plotter.Values, error) {
 	return calories, nil
 }
 
-func findHighestCalorieCounts(input string, elves int) (int, *plot.Plot, error) {
+// Total of the given number of elves carrying the most, along with every
+// elf's total from least to most
+func findHighestCalorieCounts(input string, elves int) (int, plotter.Values, error) {
 	calories, err := calculateCalorieCounts(input)
 	if err != nil {
 		return -1, nil, err
@@ -51,53 +62,55 @@ func findHighestCalorieCounts(input string, elves int) (int, *plot.Plot, error)
 	for i := 0; i < elves; i++ {
 		highestCalorieCount += int(calories[len(calories)-i-1])
 	}
+	return highestCalorieCount, calories, nil
+}
 
-	w := vg.Points(1)
-	bars, err := plotter.NewBarChart(calories, w)
-	if err != nil {
-		fmt.Println("Failed to create plot, err: ", err)
-		return highestCalorieCount, nil, err
-	}
+// Bar chart of the sorted totals with the highest few marked
+func plotCalorieCounts(calories plotter.Values, elves int, name string) Visualizer {
+	return func() (Visual, error) {
+		w := vg.Points(1)
+		bars, err := plotter.NewBarChart(calories, w)
+		if err != nil {
+			return nil, err
+		}
 
-	plt := plot.New()
-	plt.Add(bars)
+		plt := plot.New()
+		plt.Add(bars)
 
-	for i := 0; i < elves; i++ {
-		pts := make(plotter.XYs, 1)
-		pts[0].X = float64(len(calories) - i - 1)
-		pts[0].Y = calories[len(calories)-i-1]
-		s, err := plotter.NewScatter(pts)
-		if err != nil {
-			fmt.Println("Failed to plot point ", i)
-			continue
+		for i := 0; i < elves; i++ {
+			pts := make(plotter.XYs, 1)
+			pts[0].X = float64(len(calories) - i - 1)
+			pts[0].Y = calories[len(calories)-i-1]
+			s, err := plotter.NewScatter(pts)
+			if err != nil {
+				return nil, err
+			}
+			s.GlyphStyle.Color = color.RGBA{R: 255, A: 255}
+			plt.Add(s)
 		}
-		s.GlyphStyle.Color = color.RGBA{R: 255, A: 255}
-		plt.Add(s)
-	}
-	plt.Title.Text = "Total Calories Per Elf"
-	plt.X.Label.Text = "Elf"
-	plt.Y.Label.Text = "Total Calories"
+		plt.Title.Text = "Total Calories Per Elf"
+		plt.X.Label.Text = "Elf"
+		plt.Y.Label.Text = "Total Calories"
 
-	return highestCalorieCount, plt, nil
+		return PlotVisual{Plot: plt, Name: name}, nil
+	}
 }
 
 type Day1Solver struct {
 }
 
-func (d Day1Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
-	solution, sol_plt, err := findHighestCalorieCounts(puzzleInput, 1)
+func (d Day1Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	solution, calories, err := findHighestCalorieCounts(puzzleInput, 1)
 	if err != nil {
-		return strconv.Itoa(solution), nil, err
+		return Result{Answer: strconv.Itoa(solution)}, err
 	}
-	img, err := plotToImage(sol_plt, "day1partA.png")
-	return strconv.Itoa(solution), img, err
+	return Result{Answer: strconv.Itoa(solution), Visual: plotCalorieCounts(calories, 1, "day1partA.png")}, nil
 }
 
-func (d Day1Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	solution, sol_plt, err := findHighestCalorieCounts(puzzleInput, 3)
+func (d Day1Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	solution, calories, err := findHighestCalorieCounts(puzzleInput, 3)
 	if err != nil {
-		return strconv.Itoa(solution), nil, err
+		return Result{Answer: strconv.Itoa(solution)}, err
 	}
-	img, err := plotToImage(sol_plt, "day1partB.png")
-	return strconv.Itoa(solution), img, err
+	return Result{Answer: strconv.Itoa(solution), Visual: plotCalorieCounts(calories, 3, "day1partB.png")}, nil
 }
@@ -1,29 +1,40 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"math"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/widget"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      10,
+		Title:       "Cathode-Ray Tube",
+		PartATests:  day10TestsPartA,
+		PartBTests:  day10TestsPartB,
+		PartAPrompt: "Find the signal strength during the 20th, 60th, 100th, 140th, 180th, and 220th cycles. What is the sum of these six signal strengths?",
+		PartBPrompt: "Render the image given by your program. What eight capital letters appear on your CRT?",
+		Solver:      Day10Solver{},
+	})
+}
+
 type Day10Solver struct {
 }
 
-func (d Day10Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day10Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	inst, err := buildSimpleCpuInstructions(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	cpu := SimpleCpu{Instructions: inst, X: 1, Cycle: 0}
 	signalStrengths := make([]int, 0, 6)
 	for !cpu.Finsihed() {
 		v, err := cpu.Tick()
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
 		if cpu.Cycle == 20 || ((cpu.Cycle-20)%40 == 0) {
 			signalStrengths = append(signalStrengths, cpu.Cycle*v)
@@ -35,31 +46,35 @@ func (d Day10Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject,
 		sumStrengths += ss
 	}
 
-	return strconv.Itoa(sumStrengths), nil, nil
+	return Result{Answer: strconv.Itoa(sumStrengths)}, nil
 }
 
-func (d Day10Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day10Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	inst, err := buildSimpleCpuInstructions(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	cpu := SimpleCpu{Instructions: inst, X: 1, Cycle: 0}
 	var display [240]bool
 	for !cpu.Finsihed() {
 		v, err := cpu.Tick()
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
 		drawPos := (cpu.Cycle - 1) % 240
 		display[drawPos] = (math.Abs(float64(v-(drawPos%40))) <= 1)
 	}
 
 	img, err := createDisplay(&display)
-	return "(See image!)", img, err
+	if err != nil {
+		return Result{}, err
+	}
+	// The letters on the screen are the answer, so the screen itself is what gets compared
+	return Result{Answer: strings.TrimPrefix(img.Text, "\n"), Kind: ImageOnlyAnswer, Visual: visualOf(img)}, nil
 
 }
 
-func createDisplay(input *[240]bool) (fyne.CanvasObject, error) {
+func createDisplay(input *[240]bool) (TextVisual, error) {
 	var sb strings.Builder
 	for i, b := range input {
 		if i%40 == 0 {
@@ -72,10 +87,7 @@ func createDisplay(input *[240]bool) (fyne.CanvasObject, error) {
 		}
 	}
 
-	label := widget.NewLabel(sb.String())
-	label.TextStyle.Monospace = true
-
-	return label, nil
+	return TextVisual{Text: sb.String(), Monospace: true}, nil
 }
 
 type SimpleCpuInstruction struct {
@@ -133,12 +145,15 @@ func buildSimpleCpuInstructions(input string) ([]SimpleCpuInstruction, error) {
 		case 2:
 			var err error
 			insts[i].Command = parts[0]
-			insts[i].Quantity, err = strconv.Atoi(parts[1])
+			insts[i].Quantity, err = atoiField(i, line, parts[1])
 			if err != nil {
 				return insts, err
 			}
 		default:
-			return insts, errors.New("failed to parse instruction with too many parts: " + line)
+			return insts, lineError(i, line, "failed to parse instruction with too many parts")
+		}
+		if insts[i].Command != "noop" && insts[i].Command != "addx" {
+			return insts, lineError(i, line, "unknown command").atIndex(0)
 		}
 	}
 	return insts, nil
@@ -1,33 +1,52 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"sort"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"github.com/gammazero/deque"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      11,
+		Title:       "Monkey in the Middle",
+		PartATests:  day11TestsPartA,
+		PartBTests:  day11TestsPartB,
+		PartAPrompt: "What is the level of monkey business after 20 rounds of stuff-slinging simian shenanigans?",
+		PartBPrompt: "What is the level of monkey business after 10000 rounds?",
+		PartAParams: []*Param{&day11RoundsA},
+		PartBParams: []*Param{&day11RoundsB},
+		Solver:      Day11Solver{},
+	})
+}
+
+var day11RoundsA = Param{Name: "Rounds", Description: "Rounds of monkeys throwing items", Default: 20}
+
+var day11RoundsB = Param{Name: "Rounds", Description: "Rounds of monkeys throwing items", Default: 10000}
+
 type Day11Solver struct {
 }
 
-func (d Day11Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return calculateMonkeyBusiness(puzzleInput, true, 20)
+func (d Day11Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	return calculateMonkeyBusiness(ctx, puzzleInput, true, int(day11RoundsA.Get(params)))
 }
 
-func (d Day11Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return calculateMonkeyBusiness(puzzleInput, false, 10000)
+func (d Day11Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	return calculateMonkeyBusiness(ctx, puzzleInput, false, int(day11RoundsB.Get(params)))
 }
 
-func calculateMonkeyBusiness(puzzleInput string, partA bool, numRounds int) (string, fyne.CanvasObject, error) {
+func calculateMonkeyBusiness(ctx context.Context, puzzleInput string, partA bool, numRounds int) (Result, error) {
 	ms, err := buildMonkeys(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	inspectionHistory := make([]plotter.XYs, len(ms))
@@ -45,7 +64,11 @@ func calculateMonkeyBusiness(puzzleInput string, partA bool, numRounds int) (str
 	}
 
 	for i := 0; i < numRounds; i++ {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
 		ms.performRound(partA, modulator)
+		reportProgress(ctx, float64(i+1)/float64(numRounds), Counter{Name: "Rounds completed", Value: i + 1})
 		for m := 0; m < len(ms); m++ {
 			inspectionHistory[m][i+1].X = float64(i + 1)
 			inspectionHistory[m][i+1].Y = float64(ms[m].totalInspections)
@@ -60,46 +83,48 @@ func calculateMonkeyBusiness(puzzleInput string, partA bool, numRounds int) (str
 	sort.Ints(finalValues)
 	result := finalValues[len(finalValues)-1] * finalValues[len(finalValues)-2]
 
-	plt := plot.New()
-	plt.Title.Text = "Monkey Inspection Counts"
-	plt.X.Label.Text = "Round"
-	plt.Y.Label.Text = "Total Items Inspected"
+	img := func() (Visual, error) {
+		plt := plot.New()
+		plt.Title.Text = "Monkey Inspection Counts"
+		plt.X.Label.Text = "Round"
+		plt.Y.Label.Text = "Total Items Inspected"
 
-	// Mostly a reimplementation of plotutil.AddLinePoints() because I don't know how to alternate string and data
-	for i, hist := range inspectionHistory {
-		if partA {
-			line, s, err := plotter.NewLinePoints(hist)
-			if err != nil {
-				return strconv.Itoa(result), nil, err
-			}
-			line.Color = plotutil.Color(i)
-			line.Dashes = plotutil.Dashes(i)
-			s.Color = plotutil.Color(i)
-			s.Shape = plotutil.Shape(i)
-			plt.Add(line)
-			plt.Add(s)
-			plt.Legend.Add("Monkey "+strconv.Itoa(i), line, s)
-		} else {
-			line, err := plotter.NewLine(hist)
-			if err != nil {
-				return strconv.Itoa(result), nil, err
+		// Mostly a reimplementation of plotutil.AddLinePoints() because I don't know how to alternate string and data
+		for i, hist := range inspectionHistory {
+			if partA {
+				line, s, err := plotter.NewLinePoints(hist)
+				if err != nil {
+					return nil, err
+				}
+				line.Color = plotutil.Color(i)
+				line.Dashes = plotutil.Dashes(i)
+				s.Color = plotutil.Color(i)
+				s.Shape = plotutil.Shape(i)
+				plt.Add(line)
+				plt.Add(s)
+				plt.Legend.Add("Monkey "+strconv.Itoa(i), line, s)
+			} else {
+				line, err := plotter.NewLine(hist)
+				if err != nil {
+					return nil, err
+				}
+				line.Color = plotutil.Color(i)
+				line.Dashes = plotutil.Dashes(i)
+				plt.Add(line)
+				plt.Legend.Add("Monkey "+strconv.Itoa(i), line)
 			}
-			line.Color = plotutil.Color(i)
-			line.Dashes = plotutil.Dashes(i)
-			plt.Add(line)
-			plt.Legend.Add("Monkey "+strconv.Itoa(i), line)
 		}
-	}
 
-	var name string
-	if partA {
-		name = "day11partA.png"
-	} else {
-		name = "day11partB.png"
+		var name string
+		if partA {
+			name = "day11partA.png"
+		} else {
+			name = "day11partB.png"
+		}
+		return PlotVisual{Plot: plt, Name: name}, nil
 	}
-	img, err := plotToImage(plt, name)
 
-	return strconv.Itoa(result), img, err
+	return Result{Answer: strconv.Itoa(result), Visual: img}, nil
 }
 
 type monkey struct {
@@ -182,40 +207,40 @@ func buildMonkeys(input string) (monkeys, error) {
 		switch i % 7 {
 		case 0:
 			if parts[0] != "Monkey" {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
 
 		case 1:
 			if parts[0] != "Starting" {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
 			for idx := 2; idx < len(parts); idx++ {
-				v, err := strconv.Atoi(strings.TrimSuffix(parts[idx], ","))
+				v, err := atoiField(i, line, strings.TrimSuffix(parts[idx], ","))
 				if err != nil {
-					return nil, errors.New("Failed to parse int at position " + strconv.Itoa(idx) + " in line: " + line)
+					return nil, err
 				}
 				ms[i/7].items.PushBack(v)
 			}
 
 		case 2:
 			if parts[0] != "Operation:" || len(parts) != 6 {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
 
 			_, err := strconv.Atoi(parts[3])
 			if parts[3] != "old" && err != nil {
-				return nil, errors.New("failed to parse left operand in line: " + line)
+				return nil, lineError(i, line, "failed to parse left operand").at(parts[3]).wrap(err)
 			}
 			_, err = strconv.Atoi(parts[5])
 			if parts[5] != "old" && err != nil {
-				return nil, errors.New("failed to parse right operand in line: " + line + ". " + err.Error())
+				return nil, lineError(i, line, "failed to parse right operand").atIndex(strings.LastIndex(line, parts[5])).wrap(err)
 			}
 			switch parts[4] {
 			case "+":
 			case "-":
 			case "*":
 			default:
-				return nil, errors.New("failed to parse operator in line: " + line)
+				return nil, lineError(i, line, "failed to parse operator").atIndex(strings.Index(line, " "+parts[4]+" ") + 1)
 			}
 
 			ms[i/7].leftOperand = parts[3]
@@ -224,37 +249,37 @@ func buildMonkeys(input string) (monkeys, error) {
 
 		case 3:
 			if parts[0] != "Test:" || len(parts) != 4 {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
-			v, err := strconv.Atoi(parts[3])
+			v, err := atoiField(i, line, parts[3])
 			if err != nil {
-				return nil, errors.New("failed to parse divisibility int in line: " + line)
+				return nil, err
 			}
 			ms[i/7].testDivisibleBy = v
 
 		case 4:
 			if parts[0] != "If" || parts[1] != "true:" || len(parts) != 6 {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
-			v, err := strconv.Atoi(parts[5])
+			v, err := atoiField(i, line, parts[5])
 			if err != nil {
-				return nil, errors.New("failed to parse throw target in line: " + line)
+				return nil, err
 			}
 			ms[i/7].trueTarget = v
 
 		case 5:
 			if parts[0] != "If" || parts[1] != "false:" || len(parts) != 6 {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
-			v, err := strconv.Atoi(parts[5])
+			v, err := atoiField(i, line, parts[5])
 			if err != nil {
-				return nil, errors.New("failed to parse throw target in line: " + line)
+				return nil, err
 			}
 			ms[i/7].falseTarget = v
 
 		case 6:
 			if parts[0] != "" {
-				return nil, errors.New("parser out of sync at line #" + strconv.Itoa(i) + ": " + line)
+				return nil, lineError(i, line, "parser out of sync")
 			}
 
 		}
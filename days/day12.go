@@ -1,12 +1,12 @@
 package days
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/path"
 	"gonum.org/v1/gonum/graph/simple"
@@ -14,10 +14,23 @@ import (
 	"gonum.org/v1/plot/plotter"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      12,
+		Title:       "Hill Climbing Algorithm",
+		PartATests:  day12TestsPartA,
+		PartBTests:  day12TestsPartB,
+		PartAPrompt: "What is the fewest steps required to move from your current position to the location that should get the best signal?",
+		PartBPrompt: "What is the fewest steps required to move starting from any square with elevation a to the location that should get the best signal?",
+		Solver:      Day12Solver{},
+	})
+}
+
 type Day12Solver struct {
 }
 
-func (d Day12Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day12Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	g := buildElevationGraph(puzzleInput)
 
 	// Can seem to create a new node object with the same id as the start
@@ -25,38 +38,23 @@ func (d Day12Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject,
 	sol, _ := pth.To(g.endId)
 	solStr := strconv.Itoa(len(sol) - 1)
 
-	fmt.Println(sol)
-
-	path := make(plotter.XYs, 0, len(sol))
-	for i := range sol {
-		if n, ok := sol[i].(elevationNode); ok {
-			// negate Y so it visually looks like the prompts
-			path = append(path, plotter.XY{X: float64(n.location.x), Y: float64(-n.location.y)})
-		} else {
-			return solStr, nil, errors.New("got a bad type back in path solution")
-		}
-	}
-	l, err := plotter.NewLine(path)
-	if err != nil {
-		return solStr, nil, err
-	}
-
-	plt := plot.New()
-	plt.Title.Text = "Solution Path"
-	plt.X.Label.Text = "X"
-	plt.Y.Label.Text = "Y"
-
-	plt.Add(l)
-	img, err := plotToImage(plt, "day12partA.png")
-	return solStr, img, err
+	return Result{Answer: solStr, Visual: plotElevationPath(sol, "day12partA.png")}, nil
 }
 
-func (d Day12Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day12Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	start := time.Now()
 	g := buildElevationGraph(puzzleInput)
+	timings := []Timing{{Name: "Build graph", Duration: time.Since(start)}}
 
 	// Find shortest path from end to every lowest elevation, save the shortest of these
+	start = time.Now()
 	var shortestPath []graph.Node
-	for _, lowId := range g.lowestElevationIds {
+	for i, lowId := range g.lowestElevationIds {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
+		reportProgress(ctx, float64(i)/float64(len(g.lowestElevationIds)),
+			Counter{Name: "Starting points tried", Value: i})
 		pth := path.DijkstraFrom(g.Node(lowId), g)
 		sol, _ := pth.To(g.endId)
 		if len(sol) == 0 {
@@ -68,32 +66,36 @@ func (d Day12Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject,
 		}
 	}
 
+	timings = append(timings, Timing{Name: "Shortest paths", Duration: time.Since(start)})
 	solStr := strconv.Itoa(len(shortestPath) - 1)
 
-	fmt.Println(shortestPath)
+	return Result{Answer: solStr, Visual: plotElevationPath(shortestPath, "day12partB.png"), Timings: timings}, nil
+}
 
-	path := make(plotter.XYs, 0, len(shortestPath))
-	for i := range shortestPath {
-		if n, ok := shortestPath[i].(elevationNode); ok {
-			// negate Y so it visually looks like the prompts
-			path = append(path, plotter.XY{X: float64(n.location.x), Y: float64(-n.location.y)})
-		} else {
-			return solStr, nil, errors.New("got a bad type back in path solution")
+func plotElevationPath(sol []graph.Node, name string) Visualizer {
+	return func() (Visual, error) {
+		path := make(plotter.XYs, 0, len(sol))
+		for i := range sol {
+			if n, ok := sol[i].(elevationNode); ok {
+				// negate Y so it visually looks like the prompts
+				path = append(path, plotter.XY{X: float64(n.location.x), Y: float64(-n.location.y)})
+			} else {
+				return nil, errors.New("got a bad type back in path solution")
+			}
+		}
+		l, err := plotter.NewLine(path)
+		if err != nil {
+			return nil, err
 		}
-	}
-	l, err := plotter.NewLine(path)
-	if err != nil {
-		return solStr, nil, err
-	}
 
-	plt := plot.New()
-	plt.Title.Text = "Solution Path"
-	plt.X.Label.Text = "X"
-	plt.Y.Label.Text = "Y"
+		plt := plot.New()
+		plt.Title.Text = "Solution Path"
+		plt.X.Label.Text = "X"
+		plt.Y.Label.Text = "Y"
 
-	plt.Add(l)
-	img, err := plotToImage(plt, "day12partB.png")
-	return solStr, img, err
+		plt.Add(l)
+		return PlotVisual{Plot: plt, Name: name}, nil
+	}
 }
 
 type elevationGraph struct {
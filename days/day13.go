@@ -1,42 +1,54 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"image/color"
 	"sort"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/widget"
 	"github.com/gammazero/deque"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      13,
+		Title:       "Distress Signal",
+		PartATests:  day13TestsPartA,
+		PartBTests:  day13TestsPartB,
+		PartAPrompt: "Determine which pairs of packets are already in the right order. What is the sum of the indices of those pairs?",
+		PartBPrompt: "Organize all of the packets into the correct order. What is the decoder key for the distress signal?",
+		Solver:      Day13Solver{},
+	})
+}
+
 type Day13Solver struct {
 }
 
-func (d Day13Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day13Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	lines := strings.Split(puzzleInput, "\n")
 	rightIndices := make([]int, 0, len(lines)/6)
 	wrongIndices := make([]int, 0, len(lines)/6)
 	// len+1 because there's no empty line at the end of the input
 	for i := 0; i < (len(lines)+1)/3; i++ {
-		packet, err := buildDistressPacket(lines[3*i], lines[3*i+1])
+		packet, err := buildDistressPacket(lines, 3*i)
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
 		res := isSorted(packet.first, packet.second)
 		switch res {
 		case 0:
-			return "", nil, errors.New("got two identical packets: " + lines[3*i] + ", and: " + lines[3*i+1])
+			return Result{}, lineError(3*i, lines[3*i], "got two identical packets, the other is: "+lines[3*i+1])
 		case 1:
 			rightIndices = append(rightIndices, i+1)
 		case -1:
 			wrongIndices = append(wrongIndices, i+1)
 		default:
-			return "", nil, errors.New("got unexpected return from isSorted: " + strconv.Itoa(res))
+			return Result{}, errors.New("got unexpected return from isSorted: " + strconv.Itoa(res))
 		}
 	}
 
@@ -47,60 +59,62 @@ func (d Day13Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject,
 
 	solStr := strconv.Itoa(rightSum)
 
-	rightSeries := make(plotter.XYs, len(rightIndices))
-	wrongSeries := make(plotter.XYs, len(wrongIndices))
-	for i, rdx := range rightIndices {
-		rightSeries[i].X = float64(rdx)
-		rightSeries[i].Y = 1.0
-	}
-	for i, rdx := range wrongIndices {
-		wrongSeries[i].X = float64(rdx)
-		wrongSeries[i].Y = 0.0
-	}
-	sr, err := plotter.NewScatter(rightSeries)
-	sr.Color = color.RGBA{G: 255, A: 255}
-	if err != nil {
-		return solStr, nil, err
-	}
-	sw, err := plotter.NewScatter(wrongSeries)
-	if err != nil {
-		return solStr, nil, err
+	img := func() (Visual, error) {
+		rightSeries := make(plotter.XYs, len(rightIndices))
+		wrongSeries := make(plotter.XYs, len(wrongIndices))
+		for i, rdx := range rightIndices {
+			rightSeries[i].X = float64(rdx)
+			rightSeries[i].Y = 1.0
+		}
+		for i, rdx := range wrongIndices {
+			wrongSeries[i].X = float64(rdx)
+			wrongSeries[i].Y = 0.0
+		}
+		sr, err := plotter.NewScatter(rightSeries)
+		sr.Color = color.RGBA{G: 255, A: 255}
+		if err != nil {
+			return nil, err
+		}
+		sw, err := plotter.NewScatter(wrongSeries)
+		if err != nil {
+			return nil, err
+		}
+		sw.Color = color.RGBA{R: 255, A: 255}
+
+		plt := plot.New()
+		plt.Title.Text = "Ordered Packet Pairs"
+		plt.X.Label.Text = "Packet Pair Index"
+		plt.Y.Label.Text = "Corret"
+		plt.NominalY("Wrong Order", "Right Order")
+
+		plt.Add(sr)
+		plt.Add(sw)
+		return PlotVisual{Plot: plt, Name: "day12partA.png"}, nil
 	}
-	sw.Color = color.RGBA{R: 255, A: 255}
-
-	plt := plot.New()
-	plt.Title.Text = "Ordered Packet Pairs"
-	plt.X.Label.Text = "Packet Pair Index"
-	plt.Y.Label.Text = "Corret"
-	plt.NominalY("Wrong Order", "Right Order")
-
-	plt.Add(sr)
-	plt.Add(sw)
-	img, err := plotToImage(plt, "day12partA.png")
-	return solStr, img, err
+	return Result{Answer: solStr, Visual: img}, nil
 }
 
-func (d Day13Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day13Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	lines := strings.Split(puzzleInput, "\n")
 
 	packets := make(distressDataSlice, 0)
-	for _, l := range lines {
+	for i, l := range lines {
 		if strings.TrimSpace(l) != "" {
-			p, err := buildDistressData(l)
+			p, err := buildDistressData(i, l)
 			if err != nil {
-				return "", nil, err
+				return Result{}, err
 			}
 			packets = append(packets, p)
 		}
 	}
-	// Add separators
-	sep0, err := buildDistressData("[[2]]")
+	// Add separators, they aren't part of the input so have no line number
+	sep0, err := buildDistressData(-1, "[[2]]")
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
-	sep1, err := buildDistressData("[[6]]")
+	sep1, err := buildDistressData(-1, "[[6]]")
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	packets = append(packets, sep0, sep1)
 
@@ -112,9 +126,13 @@ func (d Day13Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject,
 
 	solStr := strconv.Itoa((s0idx + 1) * (s1idx + 1))
 
-	label := widget.NewLabel("The separators get sorted into index " + strconv.Itoa((s0idx + 1)) + " and " + strconv.Itoa((s1idx + 1)))
-
-	return solStr, label, nil
+	return Result{
+		Answer: solStr,
+		Facts: []Fact{
+			{Name: "Index of [[2]]", Value: strconv.Itoa(s0idx + 1)},
+			{Name: "Index of [[6]]", Value: strconv.Itoa(s1idx + 1)},
+		},
+	}, nil
 }
 
 type distressData interface {
@@ -131,12 +149,13 @@ type distressPacket struct {
 	first, second distressData
 }
 
-func buildDistressPacket(input0, input1 string) (distressPacket, error) {
-	data0, err := buildDistressData(input0)
+// Builds the packet from lines i and i+1
+func buildDistressPacket(lines []string, i int) (distressPacket, error) {
+	data0, err := buildDistressData(i, lines[i])
 	if err != nil {
 		return distressPacket{}, err
 	}
-	data1, err := buildDistressData(input1)
+	data1, err := buildDistressData(i+1, lines[i+1])
 	if err != nil {
 		return distressPacket{}, err
 	}
@@ -144,12 +163,14 @@ func buildDistressPacket(input0, input1 string) (distressPacket, error) {
 
 }
 
-func buildDistressData(input string) (distressData, error) {
+// Parses line lineIdx of the input
+func buildDistressData(lineIdx int, input string) (distressData, error) {
 	var outer distressDataList
 	collectedRunes := make([]rune, 0)
+	collectedStart := 0
 	listQueue := deque.New[*distressDataList]()
 	listQueue.PushBack(&outer)
-	for _, r := range input {
+	for idx, r := range input {
 		switch r {
 		case '[':
 			// Create distressDataList in current location
@@ -170,7 +191,7 @@ func buildDistressData(input string) (distressData, error) {
 			}
 			v, err := strconv.Atoi(string(collectedRunes))
 			if err != nil {
-				return outer, errors.New("failed to parse int: " + string(collectedRunes))
+				return outer, lineError(lineIdx, input, "failed to parse int").atIndex(collectedStart).wrap(err)
 			}
 			tailList := listQueue.PopBack()
 			newValue := distressDataValue(v)
@@ -191,7 +212,7 @@ func buildDistressData(input string) (distressData, error) {
 			}
 			v, err := strconv.Atoi(string(collectedRunes))
 			if err != nil {
-				return outer, errors.New("failed to parse int: " + string(collectedRunes))
+				return outer, lineError(lineIdx, input, "failed to parse int").atIndex(collectedStart).wrap(err)
 			}
 			tailList := listQueue.PopBack()
 			newValue := distressDataValue(v)
@@ -201,6 +222,9 @@ func buildDistressData(input string) (distressData, error) {
 			collectedRunes = make([]rune, 0)
 		default:
 			// int
+			if len(collectedRunes) == 0 {
+				collectedStart = idx
+			}
 			collectedRunes = append(collectedRunes, r)
 			// collect rune
 		}
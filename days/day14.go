@@ -1,43 +1,50 @@
 package days
 
 import (
-	"errors"
+	"bytes"
+	"context"
+	"fmt"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/widget"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      14,
+		Title:       "Regolith Reservoir",
+		PartATests:  day14TestsPartA,
+		PartBTests:  day14TestsPartB,
+		PartAPrompt: "How many units of sand come to rest before sand starts flowing into the abyss below?",
+		PartBPrompt: "Using your scan, simulate the falling sand until the source of the sand becomes blocked. How many units of sand come to rest?",
+		Solver:      Day14Solver{},
+	})
+}
+
 type Day14Solver struct {
 }
 
-func (d Day14Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day14Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	cm, err := buildCaveMap(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	sandDropped := cm.fillCaveMapWithSand(500, 0)
-	img, err := visualizeCaveMap(cm)
-
-	return strconv.Itoa(sandDropped), img, err
+	return Result{Answer: strconv.Itoa(sandDropped), Visual: visualizeCaveMap(cm)}, nil
 }
 
-func (d Day14Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day14Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	cm, err := buildCaveMap(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	cm.obstructions[cm.maxY+2] = append(cm.obstructions[cm.maxY+2], caveObstruction{startX: -10000, endX: 10000})
 
 	sandDropped := cm.fillCaveMapWithSand(500, 0)
-	img, err := visualizeCaveMap(cm)
-
-	return strconv.Itoa(sandDropped), img, err
+	return Result{Answer: strconv.Itoa(sandDropped), Visual: visualizeCaveMap(cm)}, nil
 }
 
 type caveObstruction struct {
@@ -51,12 +58,10 @@ type caveObstructions []caveObstruction
 type caveMap struct {
 	obstructions           map[int]caveObstructions
 	minX, maxX, minY, maxY int
+	// Where each unit of sand came to rest, in order
+	sand []point
 }
 
-func (obs caveObstructions) Len() int           { return len(obs) }
-func (obs caveObstructions) Swap(i, j int)      { obs[i], obs[j] = obs[j], obs[i] }
-func (obs caveObstructions) Less(i, j int) bool { return obs[i].startX < obs[j].startX }
-
 type fallResult int
 
 const (
@@ -78,18 +83,18 @@ func buildCaveMap(input string) (*caveMap, error) {
 	lines := strings.Split(input, "\n")
 
 	re := regexp.MustCompile(`([0-9]+),([0-9]+)`)
-	for _, line := range lines {
+	for i, line := range lines {
 		points := make([]point, 0)
 		res := re.FindAllStringSubmatch(line, -1)
 		for _, r := range res {
 			if len(r) != 3 {
-				return nil, errors.New("failed to parse line: " + line + ". got regexp result: " + strings.Join(r, "\\"))
+				return nil, lineError(i, line, "expected a point like 498,4").at(r[0])
 			}
-			x, err := strconv.Atoi(r[1])
+			x, err := atoiField(i, line, r[1])
 			if err != nil {
 				return nil, err
 			}
-			y, err := strconv.Atoi(r[2])
+			y, err := atoiField(i, line, r[2])
 			if err != nil {
 				return nil, err
 			}
@@ -161,6 +166,7 @@ func (cm *caveMap) fillCaveMapWithSand(startingX int, startingY int) int {
 				// Add sand
 				cm.obstructions[y] = append(cm.obstructions[y],
 					caveObstruction{startX: x, endX: x, isSand: true})
+				cm.sand = append(cm.sand, point{x: x, y: y})
 				restingSand++
 				if x == startingX && y == startingY {
 					finished = true
@@ -217,36 +223,53 @@ func attemptSandFall(x int, y int, cm *caveMap) fallResult {
 	return blocked
 }
 
-func visualizeCaveMap(cm *caveMap) (fyne.CanvasObject, error) {
-	var sb strings.Builder
-	for y := cm.minY; y <= cm.maxY+2; y++ {
-		obs := cm.obstructions[y]
-		sort.Sort(obs)
-		currObIdx := 0
-		for x := cm.minX; x <= cm.maxX; x++ {
-			if len(obs) > 0 {
-				for currObIdx < len(obs)-1 && obs[currObIdx].endX < x {
-					currObIdx++
-				}
+// Steps through the sand coming to rest a unit at a time, from the source down
+// to the floor (part B's floor is only drawn under the rock and sand)
+func visualizeCaveMap(cm *caveMap) Visualizer {
+	return func() (Visual, error) { return caveMapPlayback(cm), nil }
+}
 
-				if obs[currObIdx].startX <= x && x <= obs[currObIdx].endX {
-					if obs[currObIdx].isSand {
-						sb.WriteString("o")
-					} else {
-						sb.WriteString("#")
-					}
-				} else {
-					sb.WriteString(".")
+func caveMapPlayback(cm *caveMap) PlaybackVisual {
+	minX, maxX := cm.minX, cm.maxX
+	for _, p := range cm.sand {
+		if p.x < minX {
+			minX = p.x
+		}
+		if p.x > maxX {
+			maxX = p.x
+		}
+	}
+	width, height := maxX-minX+1, cm.maxY+3
+
+	rock := make([][]byte, height)
+	for y := range rock {
+		rock[y] = []byte(strings.Repeat(".", width))
+		for _, ob := range cm.obstructions[y] {
+			if ob.isSand {
+				continue
+			}
+			for x := ob.startX; x <= ob.endX; x++ {
+				if x >= minX && x <= maxX {
+					rock[y][x-minX] = '#'
 				}
-			} else {
-				sb.WriteString(".")
 			}
 		}
-		sb.WriteString("\n")
 	}
 
-	label := widget.NewLabel(sb.String())
-	label.TextStyle.Monospace = true
-
-	return label, nil
+	return PlaybackVisual{
+		Frames: len(cm.sand) + 1,
+		Frame: func(i int) Visual {
+			cells := make([][]byte, height)
+			for y := range cells {
+				cells[y] = append([]byte(nil), rock[y]...)
+			}
+			for _, p := range cm.sand[:i] {
+				cells[p.y][p.x-minX] = 'o'
+			}
+			return TextVisual{Text: string(bytes.Join(cells, []byte("\n"))), Monospace: true}
+		},
+		Label: func(i int) string {
+			return fmt.Sprintf("%d of %d units of sand at rest", i, len(cm.sand))
+		},
+	}
 }
@@ -1,62 +1,65 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"math"
 	"regexp"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/widget"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      15,
+		Title:       "Beacon Exclusion Zone",
+		PartATests:  day15TestsPartA,
+		PartBTests:  day15TestsPartB,
+		PartAPrompt: "Consult the report from the sensors you just deployed. In the row where y=2000000, how many positions cannot contain a beacon?",
+		PartBPrompt: "Find the only possible position for the distress beacon. What is its tuning frequency?",
+		PartAParams: []*Param{&day15Row},
+		PartBParams: []*Param{&day15SearchLimit},
+		Solver:      Day15Solver{},
+	})
+}
+
+var day15Row = Param{Name: "Row", Description: "Which row (y) to count positions in", Default: 2000000}
+
+var day15SearchLimit = Param{Name: "Search limit", Description: "The beacon's x and y are between 0 and this", Default: 4000000}
+
 type Day15Solver struct {
 }
 
-func (d Day15Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day15Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	bs, err := buildBeacons(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
-	// Hack to determine if it's a test or the puzzle
-	if len(strings.Split(puzzleInput, "\n")) > 20 {
-		sol, img, err := countImpossibleColumns(bs, 2000000, false)
-		if err != nil {
-			return "", img, err
-		}
-		return strconv.Itoa(sol), img, nil
-	} else {
-		sol, img, err := countImpossibleColumns(bs, 10, true)
-		if err != nil {
-			return "", img, err
-		}
-		return strconv.Itoa(sol), img, nil
+	sol, img, err := countImpossibleColumns(bs, int(day15Row.Get(params)))
+	if err != nil {
+		return Result{}, err
 	}
+	return Result{Answer: strconv.Itoa(sol), Visual: img}, nil
 }
 
-func (d Day15Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day15Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	// Assume the location is on the edge of a beacon's closest circle (aka it's manhattan distance + 1)
 	// this should be valid because otherwise there would be multiple possible locations
 
 	// for each beacon
 	// for each point along it's border
-	// if point is inside valid range x=[0,4000000] and y=[0,4000000] (or [0,20] for test)
+	// if point is inside valid range x=[0,limit] and y=[0,limit]
 	// check if too close to each beacon
 	minX := 0
-	maxX := 20
+	maxX := int(day15SearchLimit.Get(params))
 	minY := 0
-	maxY := 20
-	// Hack to determine if it's a test or the puzzle
-	if len(strings.Split(puzzleInput, "\n")) > 20 {
-		maxX = 4000000
-		maxY = 4000000
-	}
+	maxY := maxX
 
 	bs, err := buildBeacons(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	solX := -1
@@ -89,9 +92,12 @@ func (d Day15Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject,
 			}
 		}
 	}
+	if solX < 0 {
+		return Result{}, errors.New("no position within the search limit of " + strconv.Itoa(maxX) + " can hold the distress beacon")
+	}
 	sol := solX*4000000 + solY
 
-	return strconv.Itoa(sol), nil, nil
+	return Result{Answer: strconv.Itoa(sol)}, nil
 }
 
 type beacon struct {
@@ -104,7 +110,10 @@ type beacon struct {
 
 type beacons []beacon
 
-func countImpossibleColumns(bs beacons, y int, test bool) (int, fyne.CanvasObject, error) {
+// Rows wider than this aren't drawn, they'd be too long to look at
+const maxDrawnRowWidth = 200
+
+func countImpossibleColumns(bs beacons, y int) (int, Visualizer, error) {
 	// Find min and max x possible using distances from beacon to closest
 	minX := 100000000
 	maxX := -100000000
@@ -124,50 +133,50 @@ func countImpossibleColumns(bs beacons, y int, test bool) (int, fyne.CanvasObjec
 	}
 
 	// Now count impossible locations in that x range for the specified y
-
 	impossiblePositions := 0
-
-	var sb strings.Builder
-	if test {
-		yStr := strconv.Itoa(y)
-		sb.WriteString(strings.Repeat(" ", len(yStr)-minX))
-		sb.WriteString("0")
-		sb.WriteString(strings.Repeat(" ", maxX-minX-1))
-		sb.WriteString("\n")
+	for x := minX; x <= maxX; x++ {
+		if bs.column(x, y) == '#' {
+			impossiblePositions++
+		}
 	}
 
-	for x := minX; x < maxX; x++ {
-		impossible := false
-		isBeacon := false
-		for _, b := range bs {
-			if x == b.closestX && y == b.closestY {
-				isBeacon = true
-				break
-			}
-			if manhattanDistance(b.x, b.y, x, y) <= b.closestManhattanDistance {
-				impossible = true
-			}
+	// The row runs from minX to maxX inclusive
+	if minX > maxX || maxX-minX+1 > maxDrawnRowWidth {
+		return impossiblePositions, nil, nil
+	}
+	img := func() (Visual, error) {
+		var sb strings.Builder
+		// Mark where x=0 is, if it's in the row
+		if minX <= 0 && 0 <= maxX {
+			sb.WriteString(strings.Repeat(" ", -minX))
+			sb.WriteString("0")
+			sb.WriteString(strings.Repeat(" ", maxX))
+			sb.WriteString("\n")
 		}
-		if isBeacon {
-			if test {
-				sb.WriteString("B")
-			}
-		} else if impossible {
-			impossiblePositions++
-			if test {
-				sb.WriteString("#")
-			}
-		} else {
-			if test {
-				sb.WriteString(".")
-			}
+		for x := minX; x <= maxX; x++ {
+			sb.WriteByte(bs.column(x, y))
 		}
+		return TextVisual{Text: sb.String(), Monospace: true}, nil
 	}
+	return impossiblePositions, img, nil
+}
 
-	label := widget.NewLabel(sb.String())
-	label.TextStyle.Monospace = true
-
-	return impossiblePositions, label, nil
+// What's known about x, y: B for a beacon, # if there can't be one and . if
+// there might be
+func (bs beacons) column(x, y int) byte {
+	impossible := false
+	for _, b := range bs {
+		if x == b.closestX && y == b.closestY {
+			return 'B'
+		}
+		if manhattanDistance(b.x, b.y, x, y) <= b.closestManhattanDistance {
+			impossible = true
+		}
+	}
+	if impossible {
+		return '#'
+	}
+	return '.'
 }
 
 func manhattanDistance(x0, y0, x1, y1 int) int {
@@ -180,24 +189,24 @@ func buildBeacons(input string) (beacons, error) {
 
 	lines := strings.Split(input, "\n")
 	bs := make(beacons, 0, len(lines))
-	for _, line := range lines {
+	for i, line := range lines {
 		parts := re.FindStringSubmatch(line)
 		if len(parts) != 5 {
-			return nil, errors.New("failed to parse line: " + line)
+			return nil, lineError(i, line, "expected: Sensor at x=N, y=N: closest beacon is at x=N, y=N")
 		}
-		bX, err := strconv.Atoi(parts[1])
+		bX, err := atoiField(i, line, parts[1])
 		if err != nil {
 			return nil, err
 		}
-		bY, err := strconv.Atoi(parts[2])
+		bY, err := atoiField(i, line, parts[2])
 		if err != nil {
 			return nil, err
 		}
-		sX, err := strconv.Atoi(parts[3])
+		sX, err := atoiField(i, line, parts[3])
 		if err != nil {
 			return nil, err
 		}
-		sY, err := strconv.Atoi(parts[4])
+		sY, err := atoiField(i, line, parts[4])
 		if err != nil {
 			return nil, err
 		}
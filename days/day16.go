@@ -1,209 +1,279 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/widget"
 	"github.com/gammazero/deque"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      16,
+		Title:       "Proboscidea Volcanium",
+		PartATests:  day16TestsPartA,
+		PartBTests:  day16TestsPartB,
+		PartAPrompt: "Work out the steps to release the most pressure in 30 minutes. What is the most pressure you can release?",
+		PartBPrompt: "With you and an elephant working together for 26 minutes, what is the most pressure you could release?",
+		PartAParams: []*Param{&day16MinutesA},
+		PartBParams: []*Param{&day16MinutesB},
+		Solver:      Day16Solver{},
+	})
+}
+
+var day16MinutesA = Param{Name: "Minutes", Description: "Time before the volcano erupts", Default: 30}
+
+var day16MinutesB = Param{Name: "Minutes", Description: "Time left after teaching the elephant", Default: 26}
+
 type Day16Solver struct {
 }
 
-func (d Day16Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day16Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	vd, err := buildValveData(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
+	}
+	g, err := compressValves(&vd)
+	if err != nil {
+		return Result{}, err
 	}
 
-	// start with lazy BFS where each node has the edges: move to each neighbor, open valve (if current is closed)
-	score, path, err := valveDepthBFS(&vd)
+	minutes := int(day16MinutesA.Get(params))
+	search, err := searchValves(ctx, g, minutes)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
-	img, err := visualizeValvePath(path)
+	best := 0
+	for opened, pressure := range search.best {
+		if pressure > search.best[best] {
+			best = opened
+		}
+	}
 
-	return strconv.Itoa(score), img, err
-}
+	img := visualizeValveRoutes(g, minutes, map[string][]valveOpening{"You": search.routes[best]})
 
-func (d Day16Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+	return Result{Answer: strconv.Itoa(search.best[best]), Visual: img}, nil
 }
 
-type valveData struct {
-	rates     map[string]int
-	neighbors map[string][]string
-}
+// You and the elephant never need to open the same valve, so the answer is the
+// best pair of routes opening disjoint sets of valves
+func (d Day16Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	vd, err := buildValveData(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+	g, err := compressValves(&vd)
+	if err != nil {
+		return Result{}, err
+	}
+
+	minutes := int(day16MinutesB.Get(params))
+	search, err := searchValves(ctx, g, minutes)
+	if err != nil {
+		return Result{}, err
+	}
 
-func valveDepthBFS(vd *valveData) (int, []valveStateNode, error) {
-	// Find the max rate as an easy way to stop searching early
-	maxRate := 0
-	for _, r := range vd.rates {
-		if r > maxRate {
-			maxRate = r
+	// within[set] is the set's subset with the best route, so each set only
+	// needs pairing with the best route that keeps out of it
+	within := make([]uint, len(search.best))
+	for i := range within {
+		set := uint(i)
+		within[set] = set
+		for v := range g.rates {
+			if sub := within[set&^(1<<v)]; set&(1<<v) != 0 && search.best[sub] > search.best[within[set]] {
+				within[set] = sub
+			}
 		}
 	}
-	frontier := deque.New[*valveStateNode](30 * len(vd.rates))
-	path := make([]valveStateNode, 0)
-	stateMap := make(map[string]bool)
-	for key := range vd.rates {
-		stateMap[key] = false
+
+	all := uint(len(search.best) - 1)
+	yours, elephants := uint(0), uint(0)
+	for set := range search.best {
+		other := within[all&^uint(set)]
+		if search.best[set]+search.best[other] > search.best[yours]+search.best[elephants] {
+			yours, elephants = uint(set), other
+		}
 	}
-	frontier.PushBack(&valveStateNode{
-		timePassed: 0,
-		score:      0,
-		valveState: stateMap,
-		location:   "AA",
-		parent:     nil})
 
-	visited := make(map[string][]visistedValveNode)
+	img := visualizeValveRoutes(g, minutes, map[string][]valveOpening{
+		"You":      search.routes[yours],
+		"Elephant": search.routes[elephants],
+	})
 
-	maxScore := 0
-	var maxNode *valveStateNode
+	return Result{Answer: strconv.Itoa(search.best[yours] + search.best[elephants]), Visual: img}, nil
+}
 
-	i := 0
+type valveData struct {
+	rates     map[string]int
+	neighbors map[string][]string
+}
 
-	for frontier.Len() > 0 {
-		current := frontier.PopFront()
-		if containsOrBetter(current.score, makeOnValvesString(current.valveState), visited[current.location]) {
-			continue
-		}
+// Bitmasks of opened valves index slices, which gets too big past this many
+const maxFlowingValves = 20
+
+// The tunnels cut down to the valves worth opening. Valves are numbered in
+// name order, with start (AA) after them, and dist holds the minutes it takes
+// to walk between any two of them.
+type valveGraph struct {
+	names []string
+	rates []int
+	start int
+	dist  [][]int
+}
 
-		v := visited[current.location]
-		v = append(v, visistedValveNode{
-			score:    current.score,
-			onValves: makeOnValvesString(current.valveState),
-		})
-		visited[current.location] = v
-
-		// v = append(v, )
-		// visited = append(visited, current)
-
-		// todo if maxrate can't beat best score, continue
-
-		if current.timePassed < 30 {
-			// Add open visit valve if possible and worthwhile
-			if !current.valveState[current.location] && vd.rates[current.location] > 0 {
-				// newStateMap := current.valveState
-				newStateMap := make(map[string]bool)
-				for id, v := range current.valveState {
-					newStateMap[id] = v
-				}
-				newStateMap[current.location] = true
-				frontier.PushBack(&valveStateNode{
-					timePassed: current.timePassed + 1,
-					score:      current.score + vd.rates[current.location]*(30-current.timePassed-1),
-					valveState: newStateMap,
-					location:   current.location,
-					parent:     current})
-				if frontier.Back().score > maxScore {
-					maxScore = frontier.Back().score
-					maxNode = frontier.Back()
-				}
-			}
-			// Add move to neighbors
-			for _, neighbor := range vd.neighbors[current.location] {
-				frontier.PushBack(&valveStateNode{
-					timePassed: current.timePassed + 1,
-					score:      current.score,
-					valveState: current.valveState,
-					location:   neighbor,
-					parent:     current})
+func compressValves(vd *valveData) (*valveGraph, error) {
+	if _, ok := vd.rates["AA"]; !ok {
+		return nil, errors.New("there's no valve AA to start from")
+	}
+	g := &valveGraph{}
+	for name, rate := range vd.rates {
+		if rate > 0 {
+			g.names = append(g.names, name)
+		}
+	}
+	if len(g.names) > maxFlowingValves {
+		return nil, fmt.Errorf("%d valves have a flow rate, can't search more than %d", len(g.names), maxFlowingValves)
+	}
+	sort.Strings(g.names)
+	for _, name := range g.names {
+		g.rates = append(g.rates, vd.rates[name])
+	}
+	g.start = len(g.names)
+
+	nodes := append(append([]string(nil), g.names...), "AA")
+	g.dist = make([][]int, len(nodes))
+	for i, from := range nodes {
+		steps := vd.tunnelDistances(from)
+		g.dist[i] = make([]int, len(nodes))
+		for j, to := range nodes {
+			d, ok := steps[to]
+			if !ok {
+				// Never worth walking to
+				d = math.MaxInt32
 			}
+			g.dist[i][j] = d
 		}
-		i++
+	}
+	return g, nil
+}
 
-		if i%10000 == 0 {
-			fmt.Println("Nodes explored: ", i, ", size of frontier: ", frontier.Len())
+// Minutes to walk from one valve to every valve reachable from it
+func (vd *valveData) tunnelDistances(from string) map[string]int {
+	dist := map[string]int{from: 0}
+	var queue deque.Deque[string]
+	queue.PushBack(from)
+	for queue.Len() > 0 {
+		current := queue.PopFront()
+		for _, next := range vd.neighbors[current] {
+			if _, seen := dist[next]; !seen {
+				dist[next] = dist[current] + 1
+				queue.PushBack(next)
+			}
 		}
 	}
+	return dist
+}
 
-	pNode := maxNode
-	for pNode != nil {
-		path = append(path, *pNode)
-		pNode = pNode.parent
-	}
+// Minute (1 based) a valve was opened
+type valveOpening struct {
+	valve, minute int
+}
 
-	return maxScore, path, nil
+// best[set] is the most pressure released by a route opening exactly the set
+// of valves (a bitmask), -1 if no route can open them all in time, and
+// routes[set] is that route
+type valveSearchResult struct {
+	best   []int
+	routes [][]valveOpening
 }
 
-func visualizeValvePath(path []valveStateNode) (fyne.CanvasObject, error) {
-	prevLoc := "AA"
-	var sb strings.Builder
-	sb.WriteString(prevLoc)
-	for i := len(path) - 2; i >= 0; i-- {
-		sb.WriteString("->")
-		if path[i].location == prevLoc {
-			sb.WriteString("[")
-			sb.WriteString(path[i].location)
-			sb.WriteString("]")
-		} else {
-			sb.WriteString(path[i].location)
-		}
-		prevLoc = path[i].location
+func searchValves(ctx context.Context, g *valveGraph, minutes int) (*valveSearchResult, error) {
+	res := &valveSearchResult{
+		best:   make([]int, 1<<len(g.rates)),
+		routes: make([][]valveOpening, 1<<len(g.rates)),
+	}
+	for set := range res.best {
+		res.best[set] = -1
 	}
 
-	label := widget.NewLabel(sb.String())
-	label.TextStyle.Monospace = true
-	return container.NewHScroll(label), nil
-}
+	var route []valveOpening
+	explored := 0
+	var visit func(at, timeLeft int, opened uint, pressure int) error
+	visit = func(at, timeLeft int, opened uint, pressure int) error {
+		if pressure > res.best[opened] {
+			res.best[opened] = pressure
+			res.routes[opened] = append([]valveOpening(nil), route...)
+		}
 
-type valveStateNode struct {
-	timePassed int
-	score      int
-	valveState map[string]bool
-	location   string
-	parent     *valveStateNode
+		explored++
+		if explored%1000 == 0 {
+			if err := cancelled(ctx); err != nil {
+				return err
+			}
+		}
+		if explored%100000 == 0 {
+			reportProgress(ctx, -1, Counter{Name: "Routes explored", Value: explored})
+		}
+
+		for v, rate := range g.rates {
+			if opened&(1<<v) != 0 {
+				continue
+			}
+			// Walk there then spend a minute opening it
+			left := timeLeft - g.dist[at][v] - 1
+			if left <= 0 {
+				continue
+			}
+			route = append(route, valveOpening{valve: v, minute: minutes - left})
+			err := visit(v, left, opened|1<<v, pressure+rate*left)
+			route = route[:len(route)-1]
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(g.start, minutes, 0, 0); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
-type visistedValveNode struct {
-	score    int
-	onValves string
+// Lists when each valve is opened and how much it releases before time runs out
+func visualizeValveRoutes(g *valveGraph, minutes int, routes map[string][]valveOpening) Visualizer {
+	return func() (Visual, error) { return valveRoutesText(g, minutes, routes), nil }
 }
 
-func containsOrBetter(score int, onValues string, nodes []visistedValveNode) bool {
-	for _, n := range nodes {
-		if onValues == n.onValves && score <= n.score {
-			return true
-		}
+func valveRoutesText(g *valveGraph, minutes int, routes map[string][]valveOpening) TextVisual {
+	who := make([]string, 0, len(routes))
+	for name := range routes {
+		who = append(who, name)
 	}
-	return false
-}
+	// "You" before "Elephant"
+	sort.Sort(sort.Reverse(sort.StringSlice(who)))
 
-func makeOnValvesString(valveState map[string]bool) string {
-	onValves := make([]string, 0)
-	for k, v := range valveState {
-		if v {
-			onValves = append(onValves, k)
+	var sb strings.Builder
+	for _, name := range who {
+		sb.WriteString(name + ":\n")
+		total := 0
+		for _, o := range routes[name] {
+			released := g.rates[o.valve] * (minutes - o.minute)
+			total += released
+			sb.WriteString(fmt.Sprintf("  minute %2d: open %s (rate %d), releasing %d\n", o.minute, g.names[o.valve], g.rates[o.valve], released))
 		}
+		sb.WriteString(fmt.Sprintf("  total %d\n", total))
 	}
-	sort.Strings(onValves)
-	return strings.Join(onValves, ",")
-}
 
-// type valvePriorityQueue []valveStateNode
-
-// func (q valvePriorityQueue) Len() int { return len(q) }
-// func (q valvePriorityQueue) Less(i, j int) bool {
-// 	// We want Pop to give us the highest, not lowest, priority so we use greater than here.
-// 	return q[i].score-q[i].timePassed > q[j].score-q[j].timePassed
-// }
-// func (q valvePriorityQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
-// func (q *valvePriorityQueue) Push(n interface{}) { *q = append(*q, n.(valveStateNode)) }
-// func (q *valvePriorityQueue) Pop() interface{} {
-// 	t := *q
-// 	var n interface{}
-// 	n, *q = t[len(t)-1], t[:len(t)-1]
-// 	return n
-// }
+	return TextVisual{Text: strings.TrimSuffix(sb.String(), "\n"), Monospace: true}
+}
 
 func buildValveData(input string) (valveData, error) {
 	vd := valveData{
@@ -214,19 +284,19 @@ func buildValveData(input string) (valveData, error) {
 
 	re0 := regexp.MustCompile(`Valve ([A-Z][A-Z]) has flow rate=([0-9]+)`)
 	re1 := regexp.MustCompile(`([A-Z][A-Z])`)
-	for _, line := range lines {
+	for i, line := range lines {
 		innerParts := strings.Split(line, ";")
 		if len(innerParts) != 2 {
-			return vd, errors.New("failed to parse ; in line: " + line)
+			return vd, lineError(i, line, "expected exactly one ;")
 		}
 		firstParts := re0.FindStringSubmatch(innerParts[0])
 		if len(firstParts) != 3 {
-			return vd, errors.New("failed to parse first half of line: " + line)
+			return vd, lineError(i, line, "expected: Valve AA has flow rate=N")
 		}
 		name := firstParts[1]
-		rate, err := strconv.Atoi(firstParts[2])
+		rate, err := atoiField(i, line, firstParts[2])
 		if err != nil {
-			return vd, nil
+			return vd, err
 		}
 		vd.rates[name] = rate
 		vd.neighbors[name] = re1.FindAllString(innerParts[1], -1)
@@ -1,46 +1,105 @@
 package days
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/widget"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      17,
+		Title:       "Pyroclastic Flow",
+		PartATests:  day17TestsPartA,
+		PartBTests:  day17TestsPartB,
+		PartAPrompt: "How many units tall will the tower of rocks be after 2022 rocks have stopped falling?",
+		PartBPrompt: "How tall will the tower be after 1000000000000 rocks have stopped?",
+		PartAParams: []*Param{&day17Rocks},
+		PartBParams: []*Param{&day17RocksB},
+		Solver:      Day17Solver{},
+	})
+}
+
+var day17Rocks = Param{Name: "Rocks", Description: "How many rocks to drop", Default: 2022}
+
+var day17RocksB = Param{Name: "Rocks", Description: "How many rocks to drop", Default: 1000000000000}
+
 type Day17Solver struct {
 }
 
-func (d Day17Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day17Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	chamber, err := buildChamber(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
-	// Drop 2022 rocks
-	rocksToDrop := 2022
-	// rocksToDrop := 10
+	rocksToDrop := int(day17Rocks.Get(params))
 	for rockIdx := 0; rockIdx < rocksToDrop; rockIdx++ {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
 		// Create rock
 		rock := buildFallingRock(rockIdx)
 		chamber.dropRock(&rock)
+		reportProgress(ctx, float64(rockIdx+1)/float64(rocksToDrop),
+			Counter{Name: "Rocks dropped", Value: rockIdx + 1},
+			Counter{Name: "Tower height", Value: chamber.maxHeight})
 	}
 
 	// Make image
 	img := visualizeChamber(chamber)
 
-	return strconv.Itoa(chamber.maxHeight), img, nil
+	return Result{Answer: strconv.Itoa(chamber.maxHeight), Visual: img}, nil
 }
 
-func (d Day17Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	// TODO: find when pattern of rocks and movement loops
-	// calculate height of first pass (might be different?)
-	// calculate height of second pass
-	// multiply by number of repeats needed
-	return "", nil, nil
+// The rocks and jets go round in loops, so once the top of the tower looks the
+// same at the same point in both loops the tower grows the same way forever
+func (d Day17Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	chamber, err := buildChamber(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+
+	rocksToDrop := day17RocksB.Get(params)
+	// heights[i] is the tower's height after i rocks
+	heights := make([]int, 0)
+	seen := make(map[chamberFingerprint]int)
+	for rockIdx := 0; int64(rockIdx) < rocksToDrop; rockIdx++ {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
+		heights = append(heights, chamber.maxHeight)
+		fp := chamber.fingerprint(rockIdx)
+		if start, ok := seen[fp]; ok {
+			// Rocks and heights past here can be more than an int holds on 32 bit
+			// platforms
+			length := rockIdx - start
+			gain := chamber.maxHeight - heights[start]
+			cycles := (rocksToDrop - int64(rockIdx)) / int64(length)
+			rest := int((rocksToDrop - int64(rockIdx)) % int64(length))
+			height := int64(chamber.maxHeight) + cycles*int64(gain) + int64(heights[start+rest]-heights[start])
+			return Result{
+				Answer: strconv.FormatInt(height, 10),
+				Facts: []Fact{
+					{Name: "Cycle starts at rock", Value: strconv.Itoa(start)},
+					{Name: "Cycle length (rocks)", Value: strconv.Itoa(length)},
+					{Name: "Height gained per cycle", Value: strconv.Itoa(gain)},
+				},
+				Visual: visualizeChamber(chamber),
+			}, nil
+		}
+		seen[fp] = rockIdx
+
+		rock := buildFallingRock(rockIdx)
+		chamber.dropRock(&rock)
+		reportProgress(ctx, -1,
+			Counter{Name: "Rocks dropped", Value: rockIdx + 1},
+			Counter{Name: "Tower height", Value: chamber.maxHeight})
+	}
+
+	// Ran out of rocks before finding a cycle
+	return Result{Answer: strconv.Itoa(chamber.maxHeight), Visual: visualizeChamber(chamber)}, nil
 }
 
 type fallingRock struct {
@@ -118,7 +177,7 @@ func buildJetDirections(input string) ([]bool, error) {
 		if dir == '>' {
 			res[i] = true
 		} else if dir != '<' {
-			return nil, errors.New("got unexpected rune at position " + strconv.Itoa(i) + ": " + string(dir))
+			return nil, lineError(0, input, "expected < or > but got "+string(dir)).atIndex(i)
 		}
 	}
 	return res, nil
@@ -204,7 +263,6 @@ func (chamber *chamberMap) dropRock(rock *fallingRock) {
 		}
 		if blocked {
 			// Found a full blocked row. Delete everything below and update offsets
-			fmt.Println("Found tetris at line ", rock.position.y+yOffset)
 			chamber.m = chamber.m[rock.position.y+yOffset-chamber.heightOffset:]
 			chamber.heightOffset = rock.position.y + yOffset
 			break
@@ -266,10 +324,32 @@ func (chamber chamberMap) isLeftFree(rock fallingRock) bool {
 	return true
 }
 
-func visualizeChamber(chamber *chamberMap) fyne.CanvasObject {
-	label := widget.NewLabel(chamber.String())
-	label.TextStyle.Monospace = true
-	return container.NewHScroll(label)
+// Rows below the top of the tower looked at when comparing states, anything
+// deeper is assumed to be covered over
+const chamberSurfaceDepth = 64
+
+// What decides how the tower grows from here: the next rock's shape, the next
+// jet and how far down from the top each column's highest rock is
+type chamberFingerprint struct {
+	rock, jetIdx int
+	surface      [7]int
+}
+
+func (c *chamberMap) fingerprint(rockIdx int) chamberFingerprint {
+	fp := chamberFingerprint{rock: rockIdx % 5, jetIdx: c.jetIdx}
+	top := c.maxHeight - c.heightOffset
+	for x := range fp.surface {
+		depth := 0
+		for y := top; y >= 0 && depth < chamberSurfaceDepth && c.m[y][x+1] == '.'; y-- {
+			depth++
+		}
+		fp.surface[x] = depth
+	}
+	return fp
+}
+
+func visualizeChamber(chamber *chamberMap) Visualizer {
+	return func() (Visual, error) { return TextVisual{Text: chamber.String(), Monospace: true}, nil }
 }
 
 func (c chamberMap) String() string {
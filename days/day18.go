@@ -1,33 +1,42 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      18,
+		Title:       "Boiling Boulders",
+		PartATests:  day18TestsPartA,
+		PartBTests:  day18TestsPartB,
+		PartAPrompt: "What is the surface area of your scanned lava droplet?",
+		PartBPrompt: "What is the exterior surface area of your scanned lava droplet?",
+		Solver:      Day18Solver{},
+	})
+}
+
 type Day18Solver struct {
 }
 
-func (d Day18Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day18Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	droplet, err := buildLavaDroplet(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
-	return strconv.Itoa(droplet.surfaceArea), nil, nil
+	return Result{Answer: strconv.Itoa(droplet.surfaceArea)}, nil
 }
 
-func (d Day18Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day18Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	droplet, err := buildLavaDroplet(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
-	intEdges := droplet.countInteriorEdges()
-	res := droplet.surfaceArea - intEdges
-	return strconv.Itoa(res), nil, nil
+	return Result{Answer: strconv.Itoa(droplet.exteriorSurfaceArea())}, nil
 }
 
 type threePoint struct {
@@ -47,22 +56,22 @@ func buildLavaDroplet(input string) (lavaDroplet, error) {
 	}
 	re := regexp.MustCompile(`([0-9]+),([0-9]+),([0-9]+)`)
 
-	for _, line := range lines {
+	for i, line := range lines {
 		parts := re.FindStringSubmatch(line)
 		if len(parts) != 4 {
-			return ld, errors.New("failed to parse line: " + line)
+			return ld, lineError(i, line, "expected a point like 2,2,2")
 		}
 		var p threePoint
 		var err error
-		p.x, err = strconv.Atoi(parts[1])
+		p.x, err = atoiField(i, line, parts[1])
 		if err != nil {
 			return ld, err
 		}
-		p.y, err = strconv.Atoi(parts[2])
+		p.y, err = atoiField(i, line, parts[2])
 		if err != nil {
 			return ld, err
 		}
-		p.z, err = strconv.Atoi(parts[3])
+		p.z, err = atoiField(i, line, parts[3])
 		if err != nil {
 			return ld, err
 		}
@@ -85,9 +94,61 @@ func (ld *lavaDroplet) neighborsPresent(p threePoint) int {
 	return neighbors
 }
 
-func (ld *lavaDroplet) countInteriorEdges() int {
-	// Not sure how to do this
-	return 0
+// Faces the steam can reach. Steam spreads through the air in a box one cube
+// bigger than the droplet on every side, so it can get all the way round, and
+// every lava face it runs into is on the outside.
+func (ld *lavaDroplet) exteriorSurfaceArea() int {
+	if len(ld.scannedPoints) == 0 {
+		return 0
+	}
+	var min, max threePoint
+	first := true
+	for p := range ld.scannedPoints {
+		if first {
+			min, max = p, p
+			first = false
+		}
+		min = threePoint{x: minInt(min.x, p.x), y: minInt(min.y, p.y), z: minInt(min.z, p.z)}
+		max = threePoint{x: maxInt(max.x, p.x), y: maxInt(max.y, p.y), z: maxInt(max.z, p.z)}
+	}
+	min = threePoint{x: min.x - 1, y: min.y - 1, z: min.z - 1}
+	max = threePoint{x: max.x + 1, y: max.y + 1, z: max.z + 1}
+	inBox := func(p threePoint) bool {
+		return p.x >= min.x && p.x <= max.x && p.y >= min.y && p.y <= max.y && p.z >= min.z && p.z <= max.z
+	}
+
+	faces := 0
+	steam := map[threePoint]bool{min: true}
+	queue := []threePoint{min}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, pn := range p.neighbors() {
+			switch {
+			case !inBox(pn) || steam[pn]:
+			case ld.scannedPoints[pn]:
+				faces++
+			default:
+				steam[pn] = true
+				queue = append(queue, pn)
+			}
+		}
+	}
+	return faces
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
 }
 
 func (p threePoint) neighbors() []threePoint {
@@ -1,33 +1,58 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"math"
 	"regexp"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      19,
+		Title:       "Not Enough Minerals",
+		PartATests:  day19TestsPartA,
+		PartBTests:  day19TestsPartB,
+		PartAPrompt: "What do you get if you add up the quality level of all of the blueprints in your list?",
+		PartBPrompt: "Determine the largest number of geodes you could open using each of the first three blueprints. What do you get if you multiply these numbers together?",
+		PartAParams: []*Param{&day19MinutesA},
+		PartBParams: []*Param{&day19MinutesB},
+		PartASlow:   true,
+		PartBSlow:   true,
+		Solver:      Day19Solver{},
+	})
+}
+
+var day19MinutesA = Param{Name: "Minutes", Description: "Time each blueprint gets to open geodes", Default: 24}
+
+var day19MinutesB = Param{Name: "Minutes", Description: "Time each blueprint gets to open geodes", Default: 32}
+
 type Day19Solver struct {
 }
 
-func (d Day19Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day19Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	lines := strings.Split(puzzleInput, "\n")
 	maxGeodes := make(plotter.Values, 0, len(lines))
 
-	for _, line := range lines {
-		bp, err := parseBlueprint(line)
-		bp.maxMinutes = 24
+	for i, line := range lines {
+		bp, err := parseBlueprint(i, line)
+		bp.maxMinutes = int(day19MinutesA.Get(params))
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
+		}
+		maxGeode, err := calculateMaxGeodes(ctx, bp)
+		if err != nil {
+			return Result{}, err
 		}
-		maxGeode := calculateMaxGeodes(bp)
 		maxGeodes = append(maxGeodes, float64(maxGeode))
+		reportProgress(ctx, float64(len(maxGeodes))/float64(len(lines)),
+			Counter{Name: "Blueprints checked", Value: len(maxGeodes)})
 
 	}
 	// todo calculate quality level sum
@@ -37,40 +62,29 @@ func (d Day19Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject,
 	}
 	totalQualityLevel := strconv.Itoa(totalQuality)
 
-	plt := plot.New()
-	plt.Title.Text = "Maximum Geodes Produced"
-	plt.X.Label.Text = "Blueprint Number"
-	plt.Y.Label.Text = "Maxiumum Geodes"
-
-	// TODO: adjust this value to make bar chart look nice
-	w := vg.Points(10)
-	bars, err := plotter.NewBarChart(maxGeodes, w)
-	if err != nil {
-		return totalQualityLevel, nil, err
-	}
-
-	plt.Add(bars)
-
-	img, err := plotToImage(plt, "day19partA.png")
-
-	return totalQualityLevel, img, err
+	return Result{Answer: totalQualityLevel, Visual: plotMaxGeodes(maxGeodes, "day19partA.png")}, nil
 }
 
-func (d Day19Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day19Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	lines := strings.Split(puzzleInput, "\n")
 	if len(lines) > 3 {
 		lines = lines[:3]
 	}
 	maxGeodes := make(plotter.Values, 0, len(lines))
 
-	for _, line := range lines {
-		bp, err := parseBlueprint(line)
-		bp.maxMinutes = 32
+	for i, line := range lines {
+		bp, err := parseBlueprint(i, line)
+		bp.maxMinutes = int(day19MinutesB.Get(params))
+		if err != nil {
+			return Result{}, err
+		}
+		maxGeode, err := calculateMaxGeodes(ctx, bp)
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
-		maxGeode := calculateMaxGeodes(bp)
 		maxGeodes = append(maxGeodes, float64(maxGeode))
+		reportProgress(ctx, float64(len(maxGeodes))/float64(len(lines)),
+			Counter{Name: "Blueprints checked", Value: len(maxGeodes)})
 
 	}
 	// todo calculate quality level sum
@@ -80,23 +94,26 @@ func (d Day19Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject,
 	}
 	totalQualityLevel := strconv.Itoa(totalQuality)
 
-	plt := plot.New()
-	plt.Title.Text = "Maximum Geodes Produced"
-	plt.X.Label.Text = "Blueprint Number"
-	plt.Y.Label.Text = "Maxiumum Geodes"
-
-	// TODO: adjust this value to make bar chart look nice
-	w := vg.Points(10)
-	bars, err := plotter.NewBarChart(maxGeodes, w)
-	if err != nil {
-		return totalQualityLevel, nil, err
-	}
+	return Result{Answer: totalQualityLevel, Visual: plotMaxGeodes(maxGeodes, "day19partB.png")}, nil
+}
 
-	plt.Add(bars)
+func plotMaxGeodes(maxGeodes plotter.Values, name string) Visualizer {
+	return func() (Visual, error) {
+		plt := plot.New()
+		plt.Title.Text = "Maximum Geodes Produced"
+		plt.X.Label.Text = "Blueprint Number"
+		plt.Y.Label.Text = "Maxiumum Geodes"
 
-	img, err := plotToImage(plt, "day19partB.png")
+		// TODO: adjust this value to make bar chart look nice
+		w := vg.Points(10)
+		bars, err := plotter.NewBarChart(maxGeodes, w)
+		if err != nil {
+			return nil, err
+		}
 
-	return totalQualityLevel, img, err
+		plt.Add(bars)
+		return PlotVisual{Plot: plt, Name: name}, nil
+	}
 }
 
 type geodeBlueprint struct {
@@ -116,7 +133,8 @@ type geodeState struct {
 	actionHistory                                      []string
 }
 
-func parseBlueprint(input string) (geodeBlueprint, error) {
+// Parses line lineIdx of the input
+func parseBlueprint(lineIdx int, input string) (geodeBlueprint, error) {
 	bp := geodeBlueprint{}
 	var err error
 	re := regexp.MustCompile(
@@ -124,29 +142,29 @@ func parseBlueprint(input string) (geodeBlueprint, error) {
 
 	parts := re.FindStringSubmatch(input)
 	if len(parts) != 7 {
-		return bp, errors.New("failed to parse line: " + input)
+		return bp, lineError(lineIdx, input, "failed to parse blueprint")
 	}
-	bp.oreRobotOre, err = strconv.Atoi(parts[1])
+	bp.oreRobotOre, err = atoiField(lineIdx, input, parts[1])
 	if err != nil {
 		return bp, err
 	}
-	bp.clayRobotOre, err = strconv.Atoi(parts[2])
+	bp.clayRobotOre, err = atoiField(lineIdx, input, parts[2])
 	if err != nil {
 		return bp, err
 	}
-	bp.obsidianRobotOre, err = strconv.Atoi(parts[3])
+	bp.obsidianRobotOre, err = atoiField(lineIdx, input, parts[3])
 	if err != nil {
 		return bp, err
 	}
-	bp.obsidianRobotClay, err = strconv.Atoi(parts[4])
+	bp.obsidianRobotClay, err = atoiField(lineIdx, input, parts[4])
 	if err != nil {
 		return bp, err
 	}
-	bp.geodeRobotOre, err = strconv.Atoi(parts[5])
+	bp.geodeRobotOre, err = atoiField(lineIdx, input, parts[5])
 	if err != nil {
 		return bp, err
 	}
-	bp.geodeRobotObsidian, err = strconv.Atoi(parts[6])
+	bp.geodeRobotObsidian, err = atoiField(lineIdx, input, parts[6])
 	if err != nil {
 		return bp, err
 	}
@@ -154,16 +172,21 @@ func parseBlueprint(input string) (geodeBlueprint, error) {
 	return bp, nil
 }
 
-func calculateMaxGeodes(bp geodeBlueprint) int {
+func calculateMaxGeodes(ctx context.Context, bp geodeBlueprint) (int, error) {
 	s := geodeState{oreRobots: 1}
 	s.actionHistory = make([]string, 0, bp.maxMinutes)
-	calculateMaxGeodesInner(&s, &bp)
-	return s.geodes
+	if err := calculateMaxGeodesInner(ctx, &s, &bp); err != nil {
+		return 0, err
+	}
+	return s.geodes, nil
 }
 
-func calculateMaxGeodesInner(state *geodeState, bp *geodeBlueprint) {
+func calculateMaxGeodesInner(ctx context.Context, state *geodeState, bp *geodeBlueprint) error {
 	//  TODO check for off by one
 	for state.minutesPassed < bp.maxMinutes {
+		if err := cancelled(ctx); err != nil {
+			return err
+		}
 		// Try all 4 next builds and see what is best
 		var stateCopies [4]geodeState
 		for i := 0; i < len(stateCopies); i++ {
@@ -171,26 +194,34 @@ func calculateMaxGeodesInner(state *geodeState, bp *geodeBlueprint) {
 		}
 		if state.waitTimeNeededForOreRobot(bp) < bp.maxMinutes-state.minutesPassed {
 			stateCopies[0].waitThenBuildOreRobot(bp)
-			calculateMaxGeodesInner(&stateCopies[0], bp)
+			if err := calculateMaxGeodesInner(ctx, &stateCopies[0], bp); err != nil {
+				return err
+			}
 		} else {
 			stateCopies[0].passTime(bp.maxMinutes-state.minutesPassed, "End wait")
 		}
 
 		if state.waitTimeNeededForClayRobot(bp) < bp.maxMinutes-state.minutesPassed {
 			stateCopies[1].waitThenBuildClayRobot(bp)
-			calculateMaxGeodesInner(&stateCopies[1], bp)
+			if err := calculateMaxGeodesInner(ctx, &stateCopies[1], bp); err != nil {
+				return err
+			}
 		} else {
 			stateCopies[1].passTime(bp.maxMinutes-state.minutesPassed, "End wait")
 		}
 		if state.clayRobots > 0 && state.waitTimeNeededForObsidianRobot(bp) < bp.maxMinutes-state.minutesPassed {
 			stateCopies[2].waitThenBuildObsidianRobot(bp)
-			calculateMaxGeodesInner(&stateCopies[2], bp)
+			if err := calculateMaxGeodesInner(ctx, &stateCopies[2], bp); err != nil {
+				return err
+			}
 		} else {
 			stateCopies[2].passTime(bp.maxMinutes-state.minutesPassed, "End wait")
 		}
 		if state.obsidianRobots > 0 && state.waitTimeNeededForGeodeRobot(bp) < bp.maxMinutes-state.minutesPassed {
 			stateCopies[3].waitThenBuildGeodeRobot(bp)
-			calculateMaxGeodesInner(&stateCopies[3], bp)
+			if err := calculateMaxGeodesInner(ctx, &stateCopies[3], bp); err != nil {
+				return err
+			}
 		} else {
 			stateCopies[3].passTime(bp.maxMinutes-state.minutesPassed, "End wait")
 		}
@@ -206,6 +237,7 @@ func calculateMaxGeodesInner(state *geodeState, bp *geodeBlueprint) {
 		}
 		*state = stateCopies[maxIndex]
 	}
+	return nil
 }
 
 func (s *geodeState) passTime(minutes int, action string) {
@@ -1,29 +1,42 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      2,
+		Title:       "Rock Paper Scissors",
+		PartATests:  day2TestsPartA,
+		PartBTests:  day2TestsPartB,
+		PartAPrompt: "What would your total score be if everything goes exactly according to your strategy guide?",
+		PartBPrompt: "Following the Elf's instructions for the second column, what would your total score be if everything goes exactly according to your strategy guide?",
+		Solver:      Day2Solver{},
+	})
+}
+
 type Day2Solver struct {
 }
 
-func (d Day2Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day2Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	return calculateRockPaperScissorsScore(puzzleInput, true)
 }
 
-func (d Day2Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day2Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	return calculateRockPaperScissorsScore(puzzleInput, false)
 }
 
-func rockPaperScissorsStringToInt(moves []string) (int, int, error) {
+func rockPaperScissorsStringToInt(i int, line string) (int, int, error) {
+	moves := strings.Split(strings.TrimSpace(line), " ")
 	if len(moves) != 2 {
-		return -1, -1, errors.New("Unexpected number of moves found (" + strconv.Itoa(len(moves)) + ")")
+		return -1, -1, lineError(i, line, "Unexpected number of moves found ("+strconv.Itoa(len(moves))+")")
 	}
 
 	first := 0
@@ -37,7 +50,7 @@ func rockPaperScissorsStringToInt(moves []string) (int, int, error) {
 	case "C":
 		first = 3
 	default:
-		return -1, -1, errors.New("Unexpected first column value: " + moves[0])
+		return -1, -1, lineError(i, line, "Unexpected first column value").at(moves[0])
 	}
 
 	switch moves[1] {
@@ -48,21 +61,20 @@ func rockPaperScissorsStringToInt(moves []string) (int, int, error) {
 	case "Z":
 		second = 3
 	default:
-		return -1, -1, errors.New("Unexpected second column value: " + moves[1])
+		return -1, -1, lineError(i, line, "Unexpected second column value").atIndex(strings.LastIndex(line, moves[1]))
 	}
 
 	return first, second, nil
 }
 
-func calculateRockPaperScissorsScore(puzzleInput string, partA bool) (string, fyne.CanvasObject, error) {
+func calculateRockPaperScissorsScore(puzzleInput string, partA bool) (Result, error) {
 	lines := strings.Split(strings.TrimSpace(puzzleInput), "\n")
 	scores := make(plotter.XYs, len(lines)+1)
 	scores = append(scores, plotter.XY{X: 0, Y: 0})
 	for i, line := range lines {
-		moves := strings.Split(strings.TrimSpace(line), " ")
-		move0, move1, err := rockPaperScissorsStringToInt(moves)
+		move0, move1, err := rockPaperScissorsStringToInt(i, line)
 		if err != nil {
-			return "", nil, errors.New(err.Error() + " in line " + strconv.Itoa(i) + ": " + line)
+			return Result{}, err
 		}
 		score := 0
 		if partA {
@@ -99,22 +111,22 @@ func calculateRockPaperScissorsScore(puzzleInput string, partA bool) (string, fy
 			Y: scores[len(scores)-1].Y + float64(score)})
 	}
 
-	plt := plot.New()
-	plt.Title.Text = "Cumulative Rock Paper Sciessors Score"
-	plt.X.Label.Text = "Round"
-	plt.Y.Label.Text = "Score"
-
-	l, err := plotter.NewLine(scores)
-
-	if err != nil {
-		return strconv.Itoa(int(scores[len(scores)-1].Y)), nil, err
-	}
-	plt.Add(l)
-
 	name := "day2partB.png"
 	if partA {
 		name = "day2partA.png"
 	}
-	img, err := plotToImage(plt, name)
-	return strconv.Itoa(int(scores[len(scores)-1].Y)), img, err
+	img := func() (Visual, error) {
+		plt := plot.New()
+		plt.Title.Text = "Cumulative Rock Paper Sciessors Score"
+		plt.X.Label.Text = "Round"
+		plt.Y.Label.Text = "Score"
+
+		l, err := plotter.NewLine(scores)
+		if err != nil {
+			return nil, err
+		}
+		plt.Add(l)
+		return PlotVisual{Plot: plt, Name: name}, nil
+	}
+	return Result{Answer: strconv.Itoa(int(scores[len(scores)-1].Y)), Visual: img}, nil
 }
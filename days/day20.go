@@ -1,17 +1,28 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      20,
+		Title:       "Grove Positioning System",
+		PartATests:  day20TestsPartA,
+		PartBTests:  day20TestsPartB,
+		PartAPrompt: "Mix your encrypted file exactly once. What is the sum of the three numbers that form the grove coordinates?",
+		PartBPrompt: "Apply the decryption key and mix your encrypted file ten times. What is the sum of the three numbers that form the grove coordinates?",
+		Solver:      Day20Solver{},
+	})
+}
+
 type Day20Solver struct {
 }
 
-func (d Day20Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day20Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	// Plan:
 	// Create a datastructure with a linkled list and a slice of pointers to the linked list items
 	// use the slice to iterate through the items in original order. Use the linked list to maintain
@@ -19,7 +30,7 @@ func (d Day20Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject,
 
 	m, err := buildMixEncryption(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	m.mix()
 
@@ -28,19 +39,23 @@ func (d Day20Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject,
 	three := m.zeroNode.getFollowingNode(3000).value
 
 	solstr := strconv.Itoa(one + two + three)
-	return solstr, nil, nil
+	return Result{Answer: solstr}, nil
 }
 
-func (d Day20Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day20Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	m, err := buildMixEncryption(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	for i := range m.originalOrder {
 		m.originalOrder[i].value *= 811589153
 	}
 	for i := 0; i < 10; i++ {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
 		m.mix()
+		reportProgress(ctx, float64(i+1)/10, Counter{Name: "Mixing rounds", Value: i + 1})
 	}
 
 	one := m.zeroNode.getFollowingNode(1000).value
@@ -48,7 +63,7 @@ func (d Day20Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject,
 	three := m.zeroNode.getFollowingNode(3000).value
 
 	solstr := strconv.Itoa(one + two + three)
-	return solstr, nil, nil
+	return Result{Answer: solstr}, nil
 }
 
 type mixEncryptNode struct {
@@ -68,14 +83,14 @@ func buildMixEncryption(input string) (mixEncryption, error) {
 	var m mixEncryption
 	m.originalOrder = make([]mixEncryptNode, len(lines))
 	for i, line := range lines {
-		val, err := strconv.Atoi(line)
+		val, err := atoiField(i, line, line)
 		if err != nil {
-			return m, nil
+			return m, err
 		}
 		m.originalOrder[i].value = val
 		if val == 0 {
 			if m.zeroNode != nil {
-				return m, errors.New("found multiple zero nodes, second at index: " + strconv.Itoa(i))
+				return m, lineError(i, line, "found multiple zero nodes")
 			}
 			m.zeroNode = &m.originalOrder[i]
 		}
@@ -91,7 +106,6 @@ func buildMixEncryption(input string) (mixEncryption, error) {
 }
 
 func (m *mixEncryption) mix() {
-	// fmt.Println(m.String())
 	for i := range m.originalOrder {
 		src := &m.originalOrder[i]
 		travel := src.value % (len(m.originalOrder) - 1)
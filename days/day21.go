@@ -1,32 +1,44 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      21,
+		Title:       "Monkey Math",
+		PartATests:  day21TestsPartA,
+		PartBTests:  day21TestsPartB,
+		PartAPrompt: "What number will the monkey named root yell?",
+		PartBPrompt: "What number do you yell to pass root's equality test?",
+		Solver:      Day21Solver{},
+	})
+}
+
 type Day21Solver struct {
 }
 
-func (d Day21Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day21Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	monkeyYellMap, err := buildMonkeyYellMap(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	res, err := monkeyYellMap.solveFor("root")
-	return strconv.Itoa(res), nil, err
+	return Result{Answer: strconv.Itoa(res)}, err
 }
 
-func (d Day21Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day21Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	monkeyYellMap, err := buildMonkeyYellMap(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	res, err := monkeyYellMap.findEquality()
-	return strconv.Itoa(res), nil, err
+	return Result{Answer: strconv.Itoa(res)}, err
 }
 
 type monkeyYell interface {
@@ -48,10 +60,10 @@ type monkeyYellMap map[string]monkeyYell
 func buildMonkeyYellMap(input string) (monkeyYellMap, error) {
 	lines := strings.Split(input, "\n")
 	m := make(monkeyYellMap, len(lines))
-	for _, line := range lines {
+	for i, line := range lines {
 		parts := strings.Split(line, ": ")
 		if len(parts) != 2 {
-			return m, errors.New("failed to parse parts from line: " + line)
+			return m, lineError(i, line, "expected a name and a yell separated by \": \"")
 		}
 		val, err := strconv.Atoi(parts[1])
 		if err == nil {
@@ -59,7 +71,7 @@ func buildMonkeyYellMap(input string) (monkeyYellMap, error) {
 		} else {
 			pieces := strings.Split(parts[1], " ")
 			if len(pieces) != 3 {
-				return m, errors.New("failed to parse pieces from line: " + line)
+				return m, lineError(i, line, "expected a number or an operation like: aaaa + bbbb").at(parts[1])
 			}
 			m[parts[0]] = monkeyYellOperation{
 				left:     pieces[0],
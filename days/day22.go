@@ -1,35 +1,65 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/widget"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      22,
+		Title:       "Monkey Map",
+		PartATests:  day22TestsPartA,
+		PartBTests:  day22TestsPartB,
+		PartAPrompt: "Follow the path given in the monkeys' notes. What is the final password?",
+		PartBPrompt: "Fold the map into a cube, then follow the path given in the monkeys' notes. What is the final password?",
+		Solver:      Day22Solver{},
+	})
+}
+
 type Day22Solver struct {
 }
 
-func (d Day22Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day22Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	mm, err := buildMonkeyMap(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	err = mm.follow()
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	sol := 1000*(mm.currentPosition.y+1) + 4*(mm.currentPosition.x+1) + int(mm.currentOrientation)
 
 	img := mm.Visualize()
-	return strconv.Itoa(sol), img, err
+	return Result{Answer: strconv.Itoa(sol), Visual: img}, err
 }
 
-func (d Day22Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+func (d Day22Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	mm, err := buildMonkeyMap(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+	mm.cube, err = foldMonkeyCube(&mm)
+	if err != nil {
+		return Result{}, err
+	}
+	err = mm.follow()
+	if err != nil {
+		return Result{}, err
+	}
+	sol := 1000*(mm.currentPosition.y+1) + 4*(mm.currentPosition.x+1) + int(mm.currentOrientation)
+
+	img := mm.Visualize()
+	return Result{
+		Answer: strconv.Itoa(sol),
+		Facts:  []Fact{{Name: "Face size", Value: strconv.Itoa(mm.cube.size)}},
+		Visual: img,
+	}, err
 }
 
 type monkeyMapOrientation int
@@ -66,6 +96,8 @@ type monkeyMap struct {
 	currentPosition    point
 	currentOrientation monkeyMapOrientation
 	directions         []monkeyMapDirection
+	// Set once the map's been folded up, nil to wrap around flat
+	cube *monkeyCube
 }
 
 func buildMonkeyMap(input string) (monkeyMap, error) {
@@ -78,11 +110,11 @@ func buildMonkeyMap(input string) (monkeyMap, error) {
 
 	mapComplete := false
 	re := regexp.MustCompile("[0-9]+|[RL]")
-	for _, line := range lines {
+	for i, line := range lines {
 		if mapComplete {
 			parts := re.FindAllString(line, -1)
 			if len(parts) == 0 {
-				return mm, errors.New("failed to parse directions")
+				return mm, lineError(i, line, "failed to parse directions")
 			}
 			for _, part := range parts {
 				if part == "R" {
@@ -90,7 +122,7 @@ func buildMonkeyMap(input string) (monkeyMap, error) {
 				} else if part == "L" {
 					mm.directions = append(mm.directions, monkeyMapDirectionTurn(false))
 				} else {
-					val, err := strconv.Atoi(part)
+					val, err := atoiField(i, line, part)
 					if err != nil {
 						return mm, err
 					}
@@ -103,7 +135,14 @@ func buildMonkeyMap(input string) (monkeyMap, error) {
 			mapComplete = true
 			continue
 		}
-		mm.rows = append(mm.rows, buildMonkeyMapRow(line))
+		row, err := buildMonkeyMapRow(i, line)
+		if err != nil {
+			return mm, err
+		}
+		mm.rows = append(mm.rows, row)
+	}
+	if len(mm.rows) == 0 {
+		return mm, lineError(0, lines[0], "expected the map before the directions")
 	}
 
 	mm.currentPosition.x = mm.rows[0].minValid
@@ -112,25 +151,27 @@ func buildMonkeyMap(input string) (monkeyMap, error) {
 	return mm, nil
 }
 
-func buildMonkeyMapRow(input string) monkeyMapRow {
+// Row of the map from line i of the input
+func buildMonkeyMapRow(i int, input string) (monkeyMapRow, error) {
 	r := monkeyMapRow{}
-	r.minValid = strings.Count(input, " ")
+	r.minValid = len(input) - len(strings.TrimLeft(input, " "))
 	r.maxValid = len(input) - 1
+	if r.minValid > r.maxValid {
+		return r, lineError(i, input, "expected some open tiles (.) or walls (#)")
+	}
 	r.occuiped = make([]bool, r.maxValid-r.minValid+1)
 	r.lastOrientation = make([]monkeyMapOrientation, r.maxValid-r.minValid+1)
-	for i := r.minValid; i <= r.maxValid; i++ {
-		if input[i] == '.' {
-			r.occuiped[i-r.minValid] = false
-		} else if input[i] == '#' {
-			r.occuiped[i-r.minValid] = true
+	for x := r.minValid; x <= r.maxValid; x++ {
+		if input[x] == '.' {
+			r.occuiped[x-r.minValid] = false
+		} else if input[x] == '#' {
+			r.occuiped[x-r.minValid] = true
 		} else {
-			panic("unexpected character " + string(input[i]) +
-				" at position " + strconv.Itoa(i) +
-				" in line " + input)
+			return r, lineError(i, input, "expected . or # but got "+strconv.Quote(string(input[x]))).atIndex(x)
 		}
-		r.lastOrientation[i-r.minValid] = -1
+		r.lastOrientation[x-r.minValid] = -1
 	}
-	return r
+	return r, nil
 }
 
 func (m *monkeyMap) follow() error {
@@ -147,103 +188,60 @@ func (m *monkeyMap) follow() error {
 	return nil
 }
 
-func (m *monkeyMap) Visualize() fyne.CanvasObject {
-	label := widget.NewLabel(m.String())
-	label.TextStyle.Monospace = true
-
-	return label
+func (m *monkeyMap) Visualize() Visualizer {
+	return func() (Visual, error) { return TextVisual{Text: m.String(), Monospace: true}, nil }
 }
 
 func (m *monkeyMap) MoveForward(amount monkeyMapDirectionMove) {
 	m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
+	for i := 0; i < int(amount); i++ {
+		next, orientation := m.ahead()
+		if m.rows[next.y].isOccupied(next.x) {
+			break
+		}
+		m.currentPosition, m.currentOrientation = next, orientation
+		m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
+	}
+}
+
+// The tile in front of the current position and which way it's faced once
+// stepped on, which only changes when walking around a cube
+func (m *monkeyMap) ahead() (point, monkeyMapOrientation) {
+	p := m.currentPosition
 	switch m.currentOrientation {
 	case monkeyRight:
-		r := &m.rows[m.currentPosition.y]
-		for i := 0; i < int(amount); i++ {
-			if m.currentPosition.x+1 > r.maxValid {
-				// attempt to roll over
-				if r.isOccupied(r.minValid) {
-					break
-				}
-				m.currentPosition.x = r.minValid
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			} else {
-				if r.isOccupied(m.currentPosition.x + 1) {
-					break
-				}
-				m.currentPosition.x++
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			}
-		}
+		p.x++
+	case monkeyDown:
+		p.y++
 	case monkeyLeft:
-		r := &m.rows[m.currentPosition.y]
-		for i := 0; i < int(amount); i++ {
-			if m.currentPosition.x-1 < r.minValid {
-				// attempt to roll over
-				if r.isOccupied(r.maxValid) {
-					break
-				}
-				m.currentPosition.x = r.maxValid
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			} else {
-				if r.isOccupied(m.currentPosition.x - 1) {
-					break
-				}
-				m.currentPosition.x--
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			}
-		}
+		p.x--
 	case monkeyUp:
-		x := m.currentPosition.x
-		for i := 0; i < int(amount); i++ {
-			if m.currentPosition.y-1 < 0 || !m.rows[m.currentPosition.y-1].contains(x) {
-				// find top (highest y) row containing x
-				newY := len(m.rows) - 1
-				for ; !m.rows[newY].contains(x); newY-- {
-					if newY+1 < 0 {
-						panic("invalid map, infite up rollover")
-					}
-				}
-				if m.rows[newY].isOccupied(x) {
-					break
-				}
-				m.currentPosition.y = newY
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			} else {
-				if m.rows[m.currentPosition.y-1].isOccupied(x) {
-					break
-				}
-				m.currentPosition.y--
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			}
-		}
-	case monkeyDown:
-		x := m.currentPosition.x
-		for i := 0; i < int(amount); i++ {
-			if m.currentPosition.y+1 >= len(m.rows) || !m.rows[m.currentPosition.y+1].contains(x) {
-				// find top (lowest y) row containing x
-				newY := 0
-				for ; !m.rows[newY].contains(x); newY++ {
-					if newY+1 >= len(m.rows) {
-						panic("invalid map, infite down rollover")
-					}
-				}
-				if m.rows[newY].isOccupied(x) {
-					break
-				}
-				m.currentPosition.y = newY
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			} else {
-				if m.rows[m.currentPosition.y+1].isOccupied(x) {
-					break
-				}
-				m.currentPosition.y++
-				m.setLastOrientation(m.currentPosition.x, m.currentPosition.y, m.currentOrientation)
-			}
-		}
+		p.y--
 	default:
 		panic("invallid monkdy orientation value: " + strconv.Itoa(int(m.currentOrientation)))
 	}
+	if p.y >= 0 && p.y < len(m.rows) && m.rows[p.y].contains(p.x) {
+		return p, m.currentOrientation
+	}
+	if m.cube != nil {
+		return m.cube.wrap(m.currentPosition, m.currentOrientation)
+	}
+
+	// Roll over to the far side of the row or column
+	p = m.currentPosition
+	switch m.currentOrientation {
+	case monkeyRight:
+		p.x = m.rows[p.y].minValid
+	case monkeyLeft:
+		p.x = m.rows[p.y].maxValid
+	case monkeyDown:
+		for p.y = 0; !m.rows[p.y].contains(p.x); p.y++ {
+		}
+	case monkeyUp:
+		for p.y = len(m.rows) - 1; !m.rows[p.y].contains(p.x); p.y-- {
+		}
+	}
+	return p, m.currentOrientation
 }
 
 func (m *monkeyMap) Rotate(right monkeyMapDirectionTurn) {
@@ -310,3 +308,139 @@ func (m *monkeyMapRow) isOccupied(x int) bool {
 	}
 	return m.occuiped[x-m.minValid]
 }
+
+// Direction or position in 3D, positions are doubled so tile centres are whole
+type cubeVec [3]int
+
+func (a cubeVec) add(b cubeVec) cubeVec { return cubeVec{a[0] + b[0], a[1] + b[1], a[2] + b[2]} }
+func (a cubeVec) scale(n int) cubeVec   { return cubeVec{a[0] * n, a[1] * n, a[2] * n} }
+func (a cubeVec) dot(b cubeVec) int     { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func (a cubeVec) neg() cubeVec          { return a.scale(-1) }
+
+// Face of the cube, at (x, y) in the map's grid of faces. right and down are
+// where the map's right and down point once folded, normal points out of the
+// cube.
+type cubeFace struct {
+	x, y                int
+	right, down, normal cubeVec
+}
+
+// Direction on the face in 3D
+func (f *cubeFace) toward(o monkeyMapOrientation) cubeVec {
+	switch o {
+	case monkeyRight:
+		return f.right
+	case monkeyDown:
+		return f.down
+	case monkeyLeft:
+		return f.right.neg()
+	default:
+		return f.down.neg()
+	}
+}
+
+// Direction on the face pointing along v (which must lie in the face)
+func (f *cubeFace) orientation(v cubeVec) monkeyMapOrientation {
+	for _, o := range []monkeyMapOrientation{monkeyRight, monkeyDown, monkeyLeft, monkeyUp} {
+		if f.toward(o) == v {
+			return o
+		}
+	}
+	panic("direction doesn't lie in the face")
+}
+
+// The map folded into a cube with sides size tiles long, centred on the origin
+type monkeyCube struct {
+	size     int
+	faces    map[point]*cubeFace
+	byNormal map[cubeVec]*cubeFace
+}
+
+// Works out the cube from the map alone: the faces are the size by size
+// blocks of tiles, and folding each one down off its neighbour in the net
+// gives which way it ends up facing
+func foldMonkeyCube(m *monkeyMap) (*monkeyCube, error) {
+	tiles := 0
+	for _, r := range m.rows {
+		tiles += r.maxValid - r.minValid + 1
+	}
+	c := &monkeyCube{faces: make(map[point]*cubeFace), byNormal: make(map[cubeVec]*cubeFace)}
+	for c.size*c.size*6 < tiles {
+		c.size++
+	}
+	if c.size*c.size*6 != tiles {
+		return nil, errors.New("the map has " + strconv.Itoa(tiles) + " tiles, which can't be six square faces")
+	}
+
+	var unfolded []point
+	for y := 0; y < len(m.rows); y += c.size {
+		for x := m.rows[y].minValid - m.rows[y].minValid%c.size; x <= m.rows[y].maxValid; x += c.size {
+			if m.rows[y].contains(x) {
+				unfolded = append(unfolded, point{x: x / c.size, y: y / c.size})
+			}
+		}
+	}
+	if len(unfolded) != 6 {
+		return nil, errors.New("the map has " + strconv.Itoa(len(unfolded)) + " faces of size " + strconv.Itoa(c.size) + ", a cube needs 6")
+	}
+
+	// The first face lies flat facing up (towards whoever's reading the map)
+	// and the rest are folded down around it
+	first := &cubeFace{x: unfolded[0].x, y: unfolded[0].y, right: cubeVec{1, 0, 0}, down: cubeVec{0, 1, 0}, normal: cubeVec{0, 0, -1}}
+	queue := []*cubeFace{first}
+	c.faces[point{x: first.x, y: first.y}] = first
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		for _, next := range []cubeFace{
+			{x: f.x + 1, y: f.y, right: f.normal.neg(), down: f.down, normal: f.right},
+			{x: f.x - 1, y: f.y, right: f.normal, down: f.down, normal: f.right.neg()},
+			{x: f.x, y: f.y + 1, right: f.right, down: f.normal.neg(), normal: f.down},
+			{x: f.x, y: f.y - 1, right: f.right, down: f.normal, normal: f.down.neg()},
+		} {
+			at := point{x: next.x, y: next.y}
+			if _, done := c.faces[at]; done || !containsPoint(unfolded, at) {
+				continue
+			}
+			next := next
+			c.faces[at] = &next
+			queue = append(queue, &next)
+		}
+	}
+	if len(c.faces) != 6 {
+		return nil, errors.New("the map's faces aren't all joined up")
+	}
+	for _, f := range c.faces {
+		if _, ok := c.byNormal[f.normal]; ok {
+			return nil, errors.New("the map's faces overlap when folded, it isn't the net of a cube")
+		}
+		c.byNormal[f.normal] = f
+	}
+	return c, nil
+}
+
+func containsPoint(points []point, p point) bool {
+	for _, q := range points {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Steps off the edge of p's face onto the face over the edge, working in 3D
+// where that's just one step forward and one step down
+func (c *monkeyCube) wrap(p point, o monkeyMapOrientation) (point, monkeyMapOrientation) {
+	from := c.faces[point{x: p.x / c.size, y: p.y / c.size}]
+	i, j := p.x%c.size, p.y%c.size
+	pos := from.normal.scale(c.size).
+		add(from.right.scale(2*i + 1 - c.size)).
+		add(from.down.scale(2*j + 1 - c.size))
+
+	forward := from.toward(o)
+	pos = pos.add(forward).add(from.normal.neg())
+	to := c.byNormal[forward]
+	i = (pos.dot(to.right) + c.size - 1) / 2
+	j = (pos.dot(to.down) + c.size - 1) / 2
+	return point{x: to.x*c.size + i, y: to.y*c.size + j}, to.orientation(from.normal.neg())
+}
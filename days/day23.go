@@ -1,14 +1,263 @@
 package days
 
-import "fyne.io/fyne/v2"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      23,
+		Title:       "Unstable Diffusion",
+		PartATests:  day23TestsPartA,
+		PartBTests:  day23TestsPartB,
+		PartAPrompt: "Simulate the Elves' process and find the smallest rectangle that contains the Elves after 10 rounds. How many empty ground tiles does that rectangle contain?",
+		PartBPrompt: "Figure out where the Elves need to go. What is the number of the first round where no Elf moves?",
+		PartAParams: []*Param{&day23Rounds},
+		Solver:      Day23Solver{},
+	})
+}
+
+var day23Rounds = Param{Name: "Rounds", Description: "Rounds of elves spreading out", Default: 10}
 
 type Day23Solver struct {
 }
 
-func (d Day23Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+func (d Day23Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	grove, err := buildElfGrove(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+
+	rounds := int(day23Rounds.Get(params))
+	for r := 0; r < rounds; r++ {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
+		grove.round()
+		reportProgress(ctx, float64(r+1)/float64(rounds), Counter{Name: "Rounds", Value: r + 1})
+	}
+
+	min, max := grove.bounds()
+	empty := (max.x-min.x+1)*(max.y-min.y+1) - len(grove.elves)
+	return Result{Answer: strconv.Itoa(empty), Visual: visualOf(grove.history.visual())}, nil
+}
+
+func (d Day23Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	grove, err := buildElfGrove(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+
+	for r := 1; ; r++ {
+		if err := cancelled(ctx); err != nil {
+			return Result{}, err
+		}
+		moved := grove.round()
+		reportProgress(ctx, -1,
+			Counter{Name: "Rounds", Value: r},
+			Counter{Name: "Elves moved", Value: moved})
+		if moved == 0 {
+			return Result{Answer: strconv.Itoa(r), Visual: visualOf(grove.history.visual())}, nil
+		}
+	}
+}
+
+// Neighbouring tiles in the order the elves consider them, each direction with
+// the three tiles that have to be empty to move that way (the first being the
+// one moved to)
+var elfDirections = [4][3]point{
+	{{x: 0, y: -1}, {x: -1, y: -1}, {x: 1, y: -1}}, // N
+	{{x: 0, y: 1}, {x: -1, y: 1}, {x: 1, y: 1}},    // S
+	{{x: -1, y: 0}, {x: -1, y: -1}, {x: -1, y: 1}}, // W
+	{{x: 1, y: 0}, {x: 1, y: -1}, {x: 1, y: 1}},    // E
+}
+
+// Elves spread out over ground with no edges, so only where they are is kept
+type elfGrove struct {
+	elves    []point
+	occupied map[point]bool
+	// Direction considered first this round, an index into elfDirections
+	first   int
+	history elfHistory
+}
+
+func buildElfGrove(input string) (*elfGrove, error) {
+	g := &elfGrove{occupied: make(map[point]bool)}
+	for y, line := range strings.Split(input, "\n") {
+		for x, c := range line {
+			switch c {
+			case '#':
+				g.elves = append(g.elves, point{x: x, y: y})
+				g.occupied[point{x: x, y: y}] = true
+			case '.':
+			default:
+				return nil, lineError(y, line, "expected . or # but got "+string(c)).atIndex(x)
+			}
+		}
+	}
+	if len(g.elves) == 0 {
+		return nil, errors.New("there aren't any elves")
+	}
+	g.history.start(g.elves)
+	return g, nil
+}
+
+func (g *elfGrove) hasNeighbour(p point) bool {
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			if (dx != 0 || dy != 0) && g.occupied[point{x: p.x + dx, y: p.y + dy}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Where the elf would like to move, ok is false if it stays put
+func (g *elfGrove) propose(p point) (to point, ok bool) {
+	if !g.hasNeighbour(p) {
+		return p, false
+	}
+	for i := 0; i < len(elfDirections); i++ {
+		dir := elfDirections[(g.first+i)%len(elfDirections)]
+		free := true
+		for _, d := range dir {
+			if g.occupied[point{x: p.x + d.x, y: p.y + d.y}] {
+				free = false
+				break
+			}
+		}
+		if free {
+			return point{x: p.x + dir[0].x, y: p.y + dir[0].y}, true
+		}
+	}
+	return p, false
+}
+
+// Moves every elf whose proposal no other elf shares, returns how many moved
+func (g *elfGrove) round() int {
+	proposals := make([]point, len(g.elves))
+	wanted := make(map[point]int)
+	for i, elf := range g.elves {
+		proposals[i] = elf
+		if to, ok := g.propose(elf); ok {
+			proposals[i] = to
+			wanted[to]++
+		}
+	}
+
+	var moves []elfMove
+	for i, to := range proposals {
+		if to == g.elves[i] || wanted[to] > 1 {
+			continue
+		}
+		delete(g.occupied, g.elves[i])
+		g.occupied[to] = true
+		g.elves[i] = to
+		moves = append(moves, elfMove{elf: i, to: to})
+	}
+	g.first = (g.first + 1) % len(elfDirections)
+	g.history.add(g.elves, moves)
+	return len(moves)
+}
+
+// Corners of the smallest rectangle holding every elf
+func (g *elfGrove) bounds() (min, max point) {
+	return pointBounds(g.elves)
+}
+
+func pointBounds(points []point) (min, max point) {
+	min, max = points[0], points[0]
+	for _, p := range points {
+		if p.x < min.x {
+			min.x = p.x
+		}
+		if p.y < min.y {
+			min.y = p.y
+		}
+		if p.x > max.x {
+			max.x = p.x
+		}
+		if p.y > max.y {
+			max.y = p.y
+		}
+	}
+	return min, max
+}
+
+type elfMove struct {
+	elf int
+	to  point
+}
+
+// Rounds between copies of every elf's position, drawing a round replays the
+// moves made since the last copy
+const elfKeyframeRounds = 20
+
+// Every round's moves, for playing the elves spreading out back
+type elfHistory struct {
+	// keyframes[k] is where the elves were after k*elfKeyframeRounds rounds
+	keyframes [][]point
+	// moves[r] were made in round r+1
+	moves [][]elfMove
+	// Covers everywhere any elf has been, so the picture doesn't jump about
+	min, max point
+}
+
+func (h *elfHistory) start(elves []point) {
+	h.keyframes = [][]point{append([]point(nil), elves...)}
+	h.min, h.max = pointBounds(elves)
+}
+
+func (h *elfHistory) add(elves []point, moves []elfMove) {
+	h.moves = append(h.moves, moves)
+	if len(h.moves)%elfKeyframeRounds == 0 {
+		h.keyframes = append(h.keyframes, append([]point(nil), elves...))
+	}
+	for _, m := range moves {
+		h.min, h.max = pointBounds([]point{h.min, h.max, m.to})
+	}
+}
+
+// Where the elves were after the given number of rounds
+func (h *elfHistory) at(round int) []point {
+	elves := append([]point(nil), h.keyframes[round/elfKeyframeRounds]...)
+	for r := round - round%elfKeyframeRounds; r < round; r++ {
+		for _, m := range h.moves[r] {
+			elves[m.elf] = m.to
+		}
+	}
+	return elves
 }
 
-func (d Day23Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+func (h *elfHistory) visual() Visual {
+	width, height := h.max.x-h.min.x+1, h.max.y-h.min.y+1
+	return PlaybackVisual{
+		Frames: len(h.moves) + 1,
+		Frame: func(i int) Visual {
+			cells := make([][]byte, height)
+			for y := range cells {
+				cells[y] = []byte(strings.Repeat(".", width))
+			}
+			for _, elf := range h.at(i) {
+				cells[elf.y-h.min.y][elf.x-h.min.x] = '#'
+			}
+			lines := make([]string, height)
+			for y := range cells {
+				lines[y] = string(cells[y])
+			}
+			return TextVisual{Text: strings.Join(lines, "\n"), Monospace: true}
+		},
+		Label: func(i int) string {
+			if i == 0 {
+				return "Starting positions"
+			}
+			return fmt.Sprintf("Round %d, %d elves moved", i, len(h.moves[i-1]))
+		},
+	}
 }
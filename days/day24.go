@@ -1,14 +1,303 @@
 package days
 
-import "fyne.io/fyne/v2"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      24,
+		Title:       "Blizzard Basin",
+		PartATests:  day24TestsPartA,
+		PartBTests:  day24TestsPartB,
+		PartAPrompt: "What is the fewest number of minutes required to avoid the blizzards and reach the goal?",
+		PartBPrompt: "What is the fewest number of minutes required to reach the goal, go back to the start, then reach the goal again?",
+		Solver:      Day24Solver{},
+	})
+}
 
 type Day24Solver struct {
 }
 
-func (d Day24Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+func (d Day24Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	b, err := buildBlizzardBasin(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+
+	path, err := b.trip(ctx, b.start, b.goal, 0)
+	if err != nil {
+		return Result{}, err
+	}
+
+	minutes := len(path) - 1
+	return Result{Answer: strconv.Itoa(minutes), Visual: visualOf(b.visualize(path))}, nil
+}
+
+func (d Day24Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	b, err := buildBlizzardBasin(puzzleInput)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Getting to each end as soon as possible leaves every option open for the
+	// next trip, since waiting at the start or goal is always safe
+	path := []point{b.start}
+	var facts []Fact
+	for i, leg := range [][2]point{{b.start, b.goal}, {b.goal, b.start}, {b.start, b.goal}} {
+		legPath, err := b.trip(ctx, leg[0], leg[1], len(path)-1)
+		if err != nil {
+			return Result{}, err
+		}
+		path = append(path, legPath[1:]...)
+		facts = append(facts, Fact{Name: fmt.Sprint("Trip ", i+1, " minutes"), Value: strconv.Itoa(len(legPath) - 1)})
+	}
+
+	minutes := len(path) - 1
+	return Result{Answer: strconv.Itoa(minutes), Facts: facts, Visual: visualOf(b.visualize(path))}, nil
+}
+
+// The valley inside the walls, with the start and goal in the gaps in the top
+// and bottom walls (at y = -1 and y = height). Blizzards wrap round inside the
+// walls, so rather than moving them each one is kept where it started and
+// worked back to from where it would be.
+type blizzardBasin struct {
+	width, height int
+	// Blizzards by starting position, [y][x]
+	right, left, up, down [][]bool
+	start, goal           point
+	// Every blizzard is back where it started after this many minutes
+	period int
+}
+
+func buildBlizzardBasin(input string) (*blizzardBasin, error) {
+	lines := strings.Split(input, "\n")
+	if len(lines) < 3 {
+		return nil, errors.New("expected a valley with a wall above and below it")
+	}
+	b := &blizzardBasin{width: len(lines[0]) - 2, height: len(lines) - 2}
+	if b.width < 1 {
+		return nil, lineError(0, lines[0], "expected a wall with a gap in it")
+	}
+
+	gap := func(i int) (point, error) {
+		line := lines[i]
+		x := strings.Index(line, ".")
+		if len(line) != b.width+2 || x < 1 || strings.Count(line, ".") != 1 || strings.Count(line, "#") != len(line)-1 {
+			return point{}, lineError(i, line, "expected a wall with one gap in it")
+		}
+		return point{x: x - 1, y: i - 1}, nil
+	}
+	var err error
+	if b.start, err = gap(0); err != nil {
+		return nil, err
+	}
+	if b.goal, err = gap(len(lines) - 1); err != nil {
+		return nil, err
+	}
+
+	for _, grid := range []*[][]bool{&b.right, &b.left, &b.up, &b.down} {
+		*grid = make([][]bool, b.height)
+		for y := range *grid {
+			(*grid)[y] = make([]bool, b.width)
+		}
+	}
+	for y := 0; y < b.height; y++ {
+		line := lines[y+1]
+		if len(line) != b.width+2 || line[0] != '#' || line[len(line)-1] != '#' {
+			return nil, lineError(y+1, line, "expected a row of the valley "+strconv.Itoa(b.width)+" wide with walls either side")
+		}
+		for x := 0; x < b.width; x++ {
+			switch line[x+1] {
+			case '>':
+				b.right[y][x] = true
+			case '<':
+				b.left[y][x] = true
+			case '^':
+				b.up[y][x] = true
+			case 'v':
+				b.down[y][x] = true
+			case '.':
+			default:
+				return nil, lineError(y+1, line, "expected ., >, <, ^ or v but got "+string(line[x+1])).atIndex(x + 1)
+			}
+		}
+	}
+
+	b.period = b.width * b.height / gcd(b.width, b.height)
+	return b, nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Non-negative remainder, for wrapping positions round the valley
+func wrapIndex(i, n int) int {
+	return ((i % n) + n) % n
+}
+
+func (b *blizzardBasin) inValley(p point) bool {
+	return p.x >= 0 && p.x < b.width && p.y >= 0 && p.y < b.height
+}
+
+// Blizzards heading each way that are at p after t minutes, as >, <, ^ and v
+func (b *blizzardBasin) blizzardsAt(p point, t int) []byte {
+	var found []byte
+	if b.right[p.y][wrapIndex(p.x-t, b.width)] {
+		found = append(found, '>')
+	}
+	if b.left[p.y][wrapIndex(p.x+t, b.width)] {
+		found = append(found, '<')
+	}
+	if b.up[wrapIndex(p.y+t, b.height)][p.x] {
+		found = append(found, '^')
+	}
+	if b.down[wrapIndex(p.y-t, b.height)][p.x] {
+		found = append(found, 'v')
+	}
+	return found
+}
+
+// Whether the expedition can stand at p after t minutes
+func (b *blizzardBasin) clear(p point, t int) bool {
+	if p == b.start || p == b.goal {
+		return true
+	}
+	return b.inValley(p) && len(b.blizzardsAt(p, t)) == 0
+}
+
+// Numbers the places the expedition can be: the valley row by row then the
+// start and goal
+func (b *blizzardBasin) index(p point) int {
+	switch p {
+	case b.start:
+		return b.width * b.height
+	case b.goal:
+		return b.width*b.height + 1
+	default:
+		return p.y*b.width + p.x
+	}
+}
+
+func (b *blizzardBasin) place(i int) point {
+	switch i {
+	case b.width * b.height:
+		return b.start
+	case b.width*b.height + 1:
+		return b.goal
+	default:
+		return point{x: i % b.width, y: i / b.width}
+	}
+}
+
+// Ways to move each minute, including staying put
+var expeditionMoves = []point{{x: 0, y: 0}, {x: 1, y: 0}, {x: 0, y: 1}, {x: -1, y: 0}, {x: 0, y: -1}}
+
+// Breadth first search over where the expedition is and the minute, which
+// only matters up to the blizzards' period. Returns where the expedition is
+// each minute from leaving at minute start to arriving.
+func (b *blizzardBasin) trip(ctx context.Context, from, to point, start int) ([]point, error) {
+	places := b.width*b.height + 2
+	// came[t%period*places+place] is where the expedition was the minute
+	// before, -1 if it's not been there at that point in the period
+	came := make([]int32, places*b.period)
+	for i := range came {
+		came[i] = -1
+	}
+	state := func(place, t int) int { return t%b.period*places + place }
+
+	frontier := []int{b.index(from)}
+	came[state(b.index(from), start)] = int32(b.index(from))
+	for t := start; len(frontier) > 0; t++ {
+		if err := cancelled(ctx); err != nil {
+			return nil, err
+		}
+		reportProgress(ctx, -1,
+			Counter{Name: "Minute", Value: t},
+			Counter{Name: "Places reachable", Value: len(frontier)})
+
+		var next []int
+		for _, place := range frontier {
+			p := b.place(place)
+			for _, m := range expeditionMoves {
+				q := point{x: p.x + m.x, y: p.y + m.y}
+				if !b.clear(q, t+1) {
+					continue
+				}
+				s := state(b.index(q), t+1)
+				if came[s] >= 0 {
+					continue
+				}
+				came[s] = int32(place)
+				if q == to {
+					// Walk back to where the trip started
+					path := []point{to}
+					for at, u := b.index(to), t+1; u > start; u-- {
+						at = int(came[state(at, u)])
+						path = append(path, b.place(at))
+					}
+					for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+						path[i], path[j] = path[j], path[i]
+					}
+					return path, nil
+				}
+				next = append(next, b.index(q))
+			}
+		}
+		frontier = next
+	}
+	return nil, errors.New("there's no way through the blizzards")
 }
 
-func (d Day24Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+// Plays back the expedition (E) moving through the blizzards minute by minute,
+// cells with more than one blizzard show how many
+func (b *blizzardBasin) visualize(path []point) Visual {
+	return PlaybackVisual{
+		Frames: len(path),
+		Frame: func(t int) Visual {
+			rows := make([][]byte, b.height+2)
+			for y := range rows {
+				rows[y] = []byte(strings.Repeat("#", b.width+2))
+			}
+			rows[b.start.y+1][b.start.x+1] = '.'
+			rows[b.goal.y+1][b.goal.x+1] = '.'
+			for y := 0; y < b.height; y++ {
+				for x := 0; x < b.width; x++ {
+					switch found := b.blizzardsAt(point{x: x, y: y}, t); len(found) {
+					case 0:
+						rows[y+1][x+1] = '.'
+					case 1:
+						rows[y+1][x+1] = found[0]
+					default:
+						rows[y+1][x+1] = byte('0' + len(found))
+					}
+				}
+			}
+			rows[path[t].y+1][path[t].x+1] = 'E'
+			lines := make([]string, len(rows))
+			for y := range rows {
+				lines[y] = string(rows[y])
+			}
+			return TextVisual{Text: strings.Join(lines, "\n"), Monospace: true}
+		},
+		Label: func(t int) string {
+			switch {
+			case t == 0:
+				return "Setting off"
+			case path[t] == path[t-1]:
+				return fmt.Sprintf("Minute %d, waiting", t)
+			default:
+				return fmt.Sprintf("Minute %d, moving to %d,%d", t, path[t].x, path[t].y)
+			}
+		},
+	}
 }
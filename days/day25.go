@@ -1,14 +1,31 @@
 package days
 
-import "fyne.io/fyne/v2"
+import "context"
+
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      25,
+		Title:       "Full of Hot Air",
+		PartATests:  day25TestsPartA,
+		PartBTests:  day25TestsPartB,
+		PartAPrompt: "TODO",
+		PartBPrompt: "TODO",
+		Solver:      Day25Solver{},
+	})
+}
 
 type Day25Solver struct {
 }
 
-func (d Day25Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+func (d Day25Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	return Result{}, ErrNotImplemented
+}
+
+func (d Day25Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	return Result{}, ErrNotImplemented
 }
 
-func (d Day25Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	return "", nil, nil
+func (d Day25Solver) Implemented(p Part) bool {
+	return false
 }
@@ -1,29 +1,41 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"sort"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      3,
+		Title:       "Rucksack Reorganization",
+		PartATests:  day3TestsPartA,
+		PartBTests:  day3TestsPartB,
+		PartAPrompt: "Find the item type that appears in both compartments of each rucksack. What is the sum of the priorities of those item types?",
+		PartBPrompt: "Find the item type that corresponds to the badges of each three-Elf group. What is the sum of the priorities of those item types?",
+		Solver:      Day3Solver{},
+	})
+}
+
 type Day3Solver struct {
 }
 
-func (d Day3Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day3Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	lines := strings.Split(strings.TrimSpace(puzzleInput), "\n")
 
 	priorities := make(plotter.XYs, 0, len(lines)+1)
 	priorities = append(priorities, plotter.XY{X: 0, Y: 0})
 
 	for i, line := range lines {
-		left, right, err := createRucksackCompartments(line)
+		left, right, err := createRucksackCompartments(i, line)
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
 		priority := 0
 		for k := range left {
@@ -37,27 +49,16 @@ func (d Day3Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, e
 			Y: priorities[len(priorities)-1].Y + float64(priority)})
 	}
 
-	plt := plot.New()
-	plt.Title.Text = "Cumulative Priority Score"
-	plt.X.Label.Text = "Rucksack"
-	plt.Y.Label.Text = "Total Priority"
-
-	l, err := plotter.NewLine(priorities)
-
-	if err != nil {
-		return strconv.Itoa(int(priorities[len(priorities)-1].Y)), nil, err
-	}
-	plt.Add(l)
-
-	img, err := plotToImage(plt, "day3partA.png")
-	return strconv.Itoa(int(priorities[len(priorities)-1].Y)), img, err
+	img := plotPriorities(priorities, "Rucksack", "day3partA.png")
+	return Result{Answer: strconv.Itoa(int(priorities[len(priorities)-1].Y)), Visual: img}, nil
 
 }
 
-func (d Day3Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
-	lines := strings.Split(strings.TrimSpace(puzzleInput), "\n")
+func (d Day3Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
+	original := strings.Split(strings.TrimSpace(puzzleInput), "\n")
+	lines := make([]string, len(original))
 	for i := range lines {
-		r := []rune(lines[i])
+		r := []rune(original[i])
 		sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
 		lines[i] = string(r)
 	}
@@ -92,7 +93,7 @@ func (d Day3Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, e
 			}
 		}
 		if badge == 0 {
-			return "", nil, errors.New("Failed to find badge for trio index " + strconv.Itoa(trio))
+			return Result{}, lineError(trio, original[trio], "Failed to find a badge shared with the next two lines")
 		}
 		badges = append(badges, badge)
 	}
@@ -109,30 +110,35 @@ func (d Day3Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, e
 		case 97 <= badge && badge < 123:
 			priority = int(badge) - 97 + 1 // gets 1 - 26
 		default:
-			return "", nil, errors.New("Invalid character " + string(badge) + " in trio index " + strconv.Itoa(i))
+			return Result{}, lineError(3*i, original[3*i], "Invalid character "+string(badge)).at(string(badge))
 		}
 		priorities = append(priorities, plotter.XY{
 			X: float64(i + 1),
 			Y: priorities[len(priorities)-1].Y + float64(priority)})
 	}
 
-	plt := plot.New()
-	plt.Title.Text = "Cumulative Priority Score"
-	plt.X.Label.Text = "Trio"
-	plt.Y.Label.Text = "Total Priority"
+	img := plotPriorities(priorities, "Trio", "day3partB.png")
+	return Result{Answer: strconv.Itoa(int(priorities[len(priorities)-1].Y)), Visual: img}, nil
+}
 
-	l, err := plotter.NewLine(priorities)
+// Line of the running total of priorities, per rucksack or trio (xLabel)
+func plotPriorities(priorities plotter.XYs, xLabel, name string) Visualizer {
+	return func() (Visual, error) {
+		plt := plot.New()
+		plt.Title.Text = "Cumulative Priority Score"
+		plt.X.Label.Text = xLabel
+		plt.Y.Label.Text = "Total Priority"
 
-	if err != nil {
-		return strconv.Itoa(int(priorities[len(priorities)-1].Y)), nil, err
+		l, err := plotter.NewLine(priorities)
+		if err != nil {
+			return nil, err
+		}
+		plt.Add(l)
+		return PlotVisual{Plot: plt, Name: name}, nil
 	}
-	plt.Add(l)
-
-	img, err := plotToImage(plt, "day3partB.png")
-	return strconv.Itoa(int(priorities[len(priorities)-1].Y)), img, err
 }
 
-func createRucksackCompartments(rucksackContents string) (map[int]bool, map[int]bool, error) {
+func createRucksackCompartments(lineIdx int, rucksackContents string) (map[int]bool, map[int]bool, error) {
 	left := map[int]bool{}
 	right := map[int]bool{}
 
@@ -147,7 +153,7 @@ func createRucksackCompartments(rucksackContents string) (map[int]bool, map[int]
 		case 97 <= b && b < 123:
 			val = int(b) - 97 + 1 // gets 1 - 26
 		default:
-			return nil, nil, errors.New("Invalid character " + string(b) + " in line: " + rucksackContents)
+			return nil, nil, lineError(lineIdx, rucksackContents, "Invalid character "+string(b)).atIndex(strings.Index(rucksackContents, string(b)))
 		}
 		if i >= totalItems/2 {
 			right[val] = true
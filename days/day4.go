@@ -1,17 +1,29 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      4,
+		Title:       "Camp Cleanup",
+		PartATests:  day4TestsPartA,
+		PartBTests:  day4TestsPartB,
+		PartAPrompt: "In how many assignment pairs does one range fully contain the other?",
+		PartBPrompt: "In how many assignment pairs do the ranges overlap?",
+		Solver:      Day4Solver{},
+	})
+}
+
 type Day4Solver struct {
 }
 
@@ -117,10 +129,10 @@ func createOverlappingPlot(pairs []sectorAssignmentPair, overlapping []bool, num
 	return plt, nil
 }
 
-func (d Day4Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day4Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	pairs, err := parseSectorAssignmentList(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	fullyOverlappingPairs := 0
@@ -132,20 +144,20 @@ func (d Day4Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, e
 		}
 	}
 
-	plt, err := createOverlappingPlot(pairs, overlapping, fullyOverlappingPairs)
-	if err != nil {
-		return strconv.Itoa(fullyOverlappingPairs), nil, err
+	img := func() (Visual, error) {
+		plt, err := createOverlappingPlot(pairs, overlapping, fullyOverlappingPairs)
+		if err != nil {
+			return nil, err
+		}
+		return PlotVisual{Plot: plt, Name: "day4partA.png"}, nil
 	}
-
-	img, err := plotToImage(plt, "day4partA.png")
-
-	return strconv.Itoa(fullyOverlappingPairs), img, err
+	return Result{Answer: strconv.Itoa(fullyOverlappingPairs), Visual: img}, nil
 }
 
-func (d Day4Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day4Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	pairs, err := parseSectorAssignmentList(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 
 	overlappingPairs := 0
@@ -157,14 +169,14 @@ func (d Day4Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, e
 		}
 	}
 
-	plt, err := createOverlappingPlot(pairs, overlapping, overlappingPairs)
-	if err != nil {
-		return strconv.Itoa(overlappingPairs), nil, err
+	img := func() (Visual, error) {
+		plt, err := createOverlappingPlot(pairs, overlapping, overlappingPairs)
+		if err != nil {
+			return nil, err
+		}
+		return PlotVisual{Plot: plt, Name: "day4partA.png"}, nil
 	}
-
-	img, err := plotToImage(plt, "day4partA.png")
-
-	return strconv.Itoa(overlappingPairs), img, err
+	return Result{Answer: strconv.Itoa(overlappingPairs), Visual: img}, nil
 }
 
 func parseSectorAssignmentList(puzzleInput string) ([]sectorAssignmentPair, error) {
@@ -175,27 +187,27 @@ func parseSectorAssignmentList(puzzleInput string) ([]sectorAssignmentPair, erro
 	for i, line := range lines {
 		res := re.FindSubmatch([]byte(line))
 		if len(res) != 5 {
-			return result, errors.New("Failed to parse line:" + line)
+			return result, lineError(i, line, "expected two ranges like 2-4,6-8")
 		}
 		var err error = nil
-		result[i].start0, err = strconv.Atoi(string(res[1]))
+		result[i].start0, err = atoiField(i, line, string(res[1]))
 		if err != nil {
-			return result, errors.New("Failed to convert " + string(res[1]) + " to an interger from line:" + line)
+			return result, err
 		}
-		result[i].end0, err = strconv.Atoi(string(res[2]))
+		result[i].end0, err = atoiField(i, line, string(res[2]))
 		if err != nil {
-			return result, errors.New("Failed to convert " + string(res[2]) + " to an interger from line:" + line)
+			return result, err
 		}
-		result[i].start1, err = strconv.Atoi(string(res[3]))
+		result[i].start1, err = atoiField(i, line, string(res[3]))
 		if err != nil {
-			return result, errors.New("Failed to convert " + string(res[3]) + " to an interger from line:" + line)
+			return result, err
 		}
-		result[i].end1, err = strconv.Atoi(string(res[4]))
+		result[i].end1, err = atoiField(i, line, string(res[4]))
 		if err != nil {
-			return result, errors.New("Failed to convert " + string(res[4]) + " to an interger from line:" + line)
+			return result, err
 		}
 		if result[i].start0 > result[i].end0 || result[i].start1 > result[i].end1 {
-			return result, errors.New("Invalid ranges for line:" + line)
+			return result, lineError(i, line, "range ends before it starts")
 		}
 	}
 
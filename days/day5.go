@@ -1,177 +1,134 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"image/color"
 	"regexp"
 	"strconv"
 	"strings"
-	"time"
 
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
 	"github.com/gammazero/deque"
 )
 
-type Day5Solver struct {
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      5,
+		Title:       "Supply Stacks",
+		PartATests:  day5TestsPartA,
+		PartBTests:  day5TestsPartB,
+		PartAPrompt: "After the rearrangement procedure completes, what crate ends up on top of each stack?",
+		PartBPrompt: "After the rearrangement procedure completes, what crate ends up on top of each stack?",
+		Solver:      Day5Solver{},
+	})
 }
 
-// TODO: determine this programatically?
-const maxElevation = 10
-
-// TODO: fix bug when animate gets called multiple times (ex test and then solve)
-// TODO: smarter approach = don't animate, instead have forward/backward buttons
-// and each click executes an instruction. Don't animate, just display
+type Day5Solver struct {
+}
 
-func (d Day5Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day5Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	config, err := parseCargoCraneConfiguration(puzzleInput)
-
-	animations := make([]*fyne.Animation, 0, len(config.instructions)*5)
-
-	container := container.NewWithoutLayout()
-
-	for x, stack := range config.stacks {
-		for y := 0; y < stack.Len(); y++ {
-			container.Add(stack.At(y))
-			stack.At(y).Move(point{x: x, y: y}.toFynePos())
-		}
-	}
-	currentAnimationIdx := 0
-
-	fmt.Println("--(Start)------------")
-	config.Visualize()
-
-	failed := false
-	for i, instruction := range config.instructions {
-		for m := 0; m < instruction.numCrates; m++ {
-			start := point{x: instruction.source - 1, y: config.stacks[instruction.source-1].Len()}.toFynePos()
-			top0 := point{x: instruction.source - 1, y: maxElevation}.toFynePos()
-			top1 := point{x: instruction.destination - 1, y: maxElevation}.toFynePos()
-			end := point{x: instruction.destination - 1, y: config.stacks[instruction.destination-1].Len() + 1}.toFynePos()
-			if config.stacks[instruction.source-1].Len() == 0 {
-				fmt.Println("Failed to process instruction #", i, ", move number ", m)
-				failed = true
-				break
-			}
-			mover := config.stacks[instruction.source-1].PopBack()
-
-			animations = append(animations, canvas.NewPositionAnimation(start, top0, time.Second*1, func(p fyne.Position) {
-				mover.Move(p)
-				canvas.Refresh(mover)
-				if p == top0 {
-					currentAnimationIdx++
-					if currentAnimationIdx < len(animations) {
-						animations[currentAnimationIdx].Start()
-					}
-
-				}
-			}))
-			animations = append(animations, canvas.NewPositionAnimation(top0, top1, time.Second*1, func(p fyne.Position) {
-				mover.Move(p)
-				canvas.Refresh(mover)
-				if p == top1 {
-					currentAnimationIdx++
-					if currentAnimationIdx < len(animations) {
-						animations[currentAnimationIdx].Start()
-					}
-				}
-			}))
-			animations = append(animations, canvas.NewPositionAnimation(top1, end, time.Second*1, func(p fyne.Position) {
-				mover.Move(p)
-				canvas.Refresh(mover)
-				if p == end {
-					currentAnimationIdx++
-					if currentAnimationIdx < len(animations) {
-						animations[currentAnimationIdx].Start()
-					}
-				}
-			}))
-
-			config.stacks[instruction.destination-1].PushBack(mover)
-		}
-
-		if failed {
-			break
-		}
+	if err != nil {
+		return Result{}, err
 	}
 
-	animations[0].Start()
-	fmt.Println("--(End)--------------")
-	config.Visualize()
-	if failed {
-		return "", container, err
+	if err := config.run(true, nil); err != nil {
+		return Result{}, err
 	}
 
-	return buildPuzzleAnswer(config.stacks), container, err
+	return Result{Answer: buildPuzzleAnswer(config.stacks), Kind: TextAnswer, Visual: visualizeCrane(puzzleInput, true)}, nil
 }
 
-func (d Day5Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day5Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	config, err := parseCargoCraneConfiguration(puzzleInput)
-
-	fmt.Println("--(Start)------------")
-	config.Visualize()
-
-	failed := false
-	for i, instruction := range config.instructions {
-		var temp deque.Deque[*canvas.Text]
-		if config.stacks[instruction.source-1].Len() < instruction.numCrates {
-			fmt.Println("Failed to process instruction #", i)
-			failed = true
-			break
-		}
-		for i = 0; i < instruction.numCrates; i++ {
-			mover := config.stacks[instruction.source-1].PopBack()
-			temp.PushBack(mover)
-		}
-		for i = 0; i < instruction.numCrates; i++ {
-			mover := temp.PopBack()
-			config.stacks[instruction.destination-1].PushBack(mover)
-
-		}
-
-		if failed {
-			break
-		}
+	if err != nil {
+		return Result{}, err
 	}
 
-	fmt.Println("--(End)--------------")
-	config.Visualize()
-	if failed {
-		return "", nil, err
+	if err := config.run(false, nil); err != nil {
+		return Result{}, err
 	}
 
-	return buildPuzzleAnswer(config.stacks), nil, err
-
+	return Result{Answer: buildPuzzleAnswer(config.stacks), Kind: TextAnswer, Visual: visualizeCrane(puzzleInput, false)}, nil
 }
 
 type point struct {
 	x, y int
 }
 
-func (p point) toFynePos() fyne.Position {
-	return fyne.Position{X: float32(20 + 20*p.x), Y: float32(20 + 20*p.y)}
-}
-
-func buildPuzzleAnswer(stacks []deque.Deque[*canvas.Text]) string {
+func buildPuzzleAnswer(stacks []deque.Deque[rune]) string {
 	var sb strings.Builder
 
 	for _, s := range stacks {
-		sb.WriteString(s.Back().Text)
+		// An empty stack has no top crate; leave a blank so the others keep their places
+		if s.Len() == 0 {
+			sb.WriteRune(' ')
+			continue
+		}
+		sb.WriteRune(s.Back())
 	}
 	return sb.String()
 }
 
 type craneInstruction struct {
 	numCrates, source, destination int
+	// Where the move came from in the input, for errors
+	line int
+	text string
+}
+
+func (i craneInstruction) String() string {
+	return fmt.Sprintf("move %d from %d to %d", i.numCrates, i.source, i.destination)
 }
 
 type craneConfiguration struct {
-	stacks       []deque.Deque[*canvas.Text]
+	stacks       []deque.Deque[rune]
 	instructions []craneInstruction
 }
 
+// Makes sure there are enough crates on the source stack for the move
+func (c craneConfiguration) check(inst craneInstruction) error {
+	if n := c.stacks[inst.source-1].Len(); n < inst.numCrates {
+		return lineError(inst.line, inst.text, fmt.Sprintf("can't move %d crates from stack %d, it only has %d", inst.numCrates, inst.source, n))
+	}
+	return nil
+}
+
+// Carries out every instruction, moving crates one at a time (part A) or a
+// whole pile at once (part B). moved, if set, is called after each step with
+// what was done.
+func (c craneConfiguration) run(oneAtATime bool, moved func(label string)) error {
+	for i, instruction := range c.instructions {
+		if err := c.check(instruction); err != nil {
+			return err
+		}
+		if oneAtATime {
+			for m := 0; m < instruction.numCrates; m++ {
+				mover := c.stacks[instruction.source-1].PopBack()
+				c.stacks[instruction.destination-1].PushBack(mover)
+				if moved != nil {
+					moved(fmt.Sprintf("Move %d, crate %d of %d: %s", i+1, m+1, instruction.numCrates, instruction))
+				}
+			}
+			continue
+		}
+
+		var temp deque.Deque[rune]
+		for m := 0; m < instruction.numCrates; m++ {
+			mover := c.stacks[instruction.source-1].PopBack()
+			temp.PushBack(mover)
+		}
+		for m := 0; m < instruction.numCrates; m++ {
+			mover := temp.PopBack()
+			c.stacks[instruction.destination-1].PushBack(mover)
+		}
+		if moved != nil {
+			moved(fmt.Sprintf("Move %d of %d: %s", i+1, len(c.instructions), instruction))
+		}
+	}
+	return nil
+}
+
 func parseCargoCraneConfiguration(input string) (craneConfiguration, error) {
 	var config craneConfiguration
 	lines := strings.Split(input, "\n")
@@ -184,22 +141,25 @@ func parseCargoCraneConfiguration(input string) (craneConfiguration, error) {
 		}
 	}
 	if split == 0 {
-		return config, errors.New("couldn't find expected empty line")
+		return config, lineError(0, lines[0], "couldn't find the empty line between the stacks and the moves")
 	}
 
 	// determine number of stacks
 	stackNumString := strings.Split(strings.TrimSpace(lines[split-1]), " ")
-	numStacks, err := strconv.Atoi(strings.TrimSpace(stackNumString[len(stackNumString)-1]))
+	numStacks, err := atoiField(split-1, lines[split-1], strings.TrimSpace(stackNumString[len(stackNumString)-1]))
 	if err != nil {
 		return config, err
 	}
-	config.stacks = make([]deque.Deque[*canvas.Text], numStacks)
+	config.stacks = make([]deque.Deque[rune], numStacks)
 
-	// Fill stacks
-	for i := numStacks - 1; i >= 0; i-- {
+	// Fill stacks from the row just above the labels up to the top
+	for i := split - 2; i >= 0; i-- {
 		for j := 1; j < len(lines[i]); j += 4 {
 			if lines[i][j] != ' ' {
-				config.stacks[j/4].PushBack(canvas.NewText(string(lines[i][j]), color.Black))
+				if j/4 >= numStacks {
+					return config, lineError(i, lines[i], "crate is beyond the last stack").atIndex(j)
+				}
+				config.stacks[j/4].PushBack(rune(lines[i][j]))
 			}
 		}
 	}
@@ -210,40 +170,108 @@ func parseCargoCraneConfiguration(input string) (craneConfiguration, error) {
 	for i := split + 1; i < len(lines); i++ {
 		res := re.FindSubmatch([]byte(lines[i]))
 		if len(res) != 4 {
-			return config, errors.New("Failed to parse line:" + lines[i])
+			return config, lineError(i, lines[i], "expected a move like: move 1 from 2 to 3")
 		}
 		var err error
-		config.instructions[i-split-1].numCrates, err = strconv.Atoi(string(res[1]))
+		config.instructions[i-split-1].numCrates, err = atoiField(i, lines[i], string(res[1]))
 		if err != nil {
 			return config, err
 		}
-		config.instructions[i-split-1].source, err = strconv.Atoi(string(res[2]))
+		config.instructions[i-split-1].source, err = atoiField(i, lines[i], string(res[2]))
 		if err != nil {
 			return config, err
 		}
-		config.instructions[i-split-1].destination, err = strconv.Atoi(string(res[3]))
+		config.instructions[i-split-1].destination, err = atoiField(i, lines[i], string(res[3]))
 		if err != nil {
 			return config, err
 		}
+		for _, stack := range []struct {
+			n      int
+			prefix string
+		}{{config.instructions[i-split-1].source, " from "}, {config.instructions[i-split-1].destination, " to "}} {
+			if stack.n < 1 || stack.n > numStacks {
+				field := stack.prefix + strconv.Itoa(stack.n)
+				return config, lineError(i, lines[i], "there's no stack "+strconv.Itoa(stack.n)).atIndex(strings.Index(lines[i], field) + len(stack.prefix))
+			}
+		}
+		config.instructions[i-split-1].line = i
+		config.instructions[i-split-1].text = lines[i]
 	}
 
 	return config, nil
 }
 
-func (c craneConfiguration) Visualize() {
-	for y := 0; y < 200; y++ {
-		var sb strings.Builder
-		for _, s := range c.stacks {
-			if y < s.Len() {
-				sb.WriteString(s.At(y).Text)
-			} else {
-				sb.WriteString(" ")
-			}
+// The stacks after each move, to be stepped through
+type cranePlayback struct {
+	states [][][]rune
+	labels []string
+}
+
+func newCranePlayback(c craneConfiguration) *cranePlayback {
+	p := &cranePlayback{}
+	p.add(c, "Starting stacks")
+	return p
+}
+
+// Snapshot of the stacks as they are now
+func (p *cranePlayback) add(c craneConfiguration, label string) {
+	stacks := make([][]rune, len(c.stacks))
+	for x := range c.stacks {
+		for y := 0; y < c.stacks[x].Len(); y++ {
+			stacks[x] = append(stacks[x], c.stacks[x].At(y))
 		}
-		if len(strings.TrimSpace(sb.String())) == 0 {
-			return
+	}
+	p.states = append(p.states, stacks)
+	p.labels = append(p.labels, label)
+}
+
+// Plays the moves again from the start, keeping the stacks after each step
+func visualizeCrane(input string, oneAtATime bool) Visualizer {
+	return func() (Visual, error) {
+		config, err := parseCargoCraneConfiguration(input)
+		if err != nil {
+			return nil, err
+		}
+		p := newCranePlayback(config)
+		if err := config.run(oneAtATime, func(label string) { p.add(config, label) }); err != nil {
+			return nil, err
+		}
+		return p.visual(), nil
+	}
+}
+
+func (p *cranePlayback) visual() PlaybackVisual {
+	return PlaybackVisual{
+		Frames: len(p.states),
+		Frame: func(i int) Visual {
+			return TextVisual{Text: stacksText(p.states[i]), Monospace: true}
+		},
+		Label: func(i int) string { return p.labels[i] },
+	}
+}
+
+// Draws the stacks (bottom to top) like the puzzle's starting drawing
+func stacksText(stacks [][]rune) string {
+	height := 0
+	for _, s := range stacks {
+		if len(s) > height {
+			height = len(s)
 		}
-		fmt.Println(sb.String())
 	}
 
+	var sb strings.Builder
+	for y := height - 1; y >= 0; y-- {
+		for _, s := range stacks {
+			if y < len(s) {
+				sb.WriteString("[" + string(s[y]) + "] ")
+			} else {
+				sb.WriteString("    ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	for i := range stacks {
+		sb.WriteString(fmt.Sprintf(" %d  ", i+1))
+	}
+	return sb.String()
 }
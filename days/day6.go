@@ -1,37 +1,45 @@
 package days
 
 import (
+	"context"
 	"errors"
-	"image/color"
 	"strconv"
 
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
 	"github.com/gammazero/deque"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      6,
+		Title:       "Tuning Trouble",
+		PartATests:  day6TestsPartA,
+		PartBTests:  day6TestsPartB,
+		PartAPrompt: "How many characters need to be processed before the first start-of-packet marker is detected?",
+		PartBPrompt: "How many characters need to be processed before the first start-of-message marker is detected?",
+		Solver:      Day6Solver{},
+	})
+}
+
 type Day6Solver struct {
 }
 
-func (d Day6Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day6Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	requiredUniqueA := 4
 	res, err := findStartOfPacket(puzzleInput, requiredUniqueA)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
-	canvas, err := buildWordDisplay(puzzleInput, res, requiredUniqueA)
-	return strconv.Itoa(res), canvas, err
+	return Result{Answer: strconv.Itoa(res), Visual: buildWordDisplay(puzzleInput, res, requiredUniqueA)}, nil
 }
 
-func (d Day6Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day6Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	requiredUniqueB := 14
 	res, err := findStartOfPacket(puzzleInput, requiredUniqueB)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
-	canvas, err := buildWordDisplay(puzzleInput, res, requiredUniqueB)
-	return strconv.Itoa(res), canvas, err
+	return Result{Answer: strconv.Itoa(res), Visual: buildWordDisplay(puzzleInput, res, requiredUniqueB)}, nil
 }
 
 func findStartOfPacket(packet string, requiredUnique int) (int, error) {
@@ -64,11 +72,6 @@ func uniqueContents(d *deque.Deque[rune]) bool {
 	return true
 }
 
-func buildWordDisplay(word string, solutionIndex int, requiredUnique int) (fyne.CanvasObject, error) {
-	t0 := canvas.NewText(word[:solutionIndex-requiredUnique], color.Black)
-	t1 := canvas.NewText(word[solutionIndex-requiredUnique:solutionIndex], color.RGBA{255, 0, 0, 255})
-	t2 := canvas.NewText(word[:solutionIndex], color.Black)
-	hbox := container.NewHBox(t0, t1, t2)
-
-	return container.NewHScroll(hbox), nil
+func buildWordDisplay(word string, solutionIndex int, requiredUnique int) Visualizer {
+	return visualOf(HighlightVisual{Text: word, Start: solutionIndex - requiredUnique, End: solutionIndex})
 }
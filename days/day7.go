@@ -1,44 +1,62 @@
 package days
 
 import (
-	"errors"
+	"context"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/widget"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      7,
+		Title:       "No Space Left On Device",
+		PartATests:  day7TestsPartA,
+		PartBTests:  day7TestsPartB,
+		PartAPrompt: "Find all of the directories with a total size of at most 100000. What is the sum of the total sizes of those directories?",
+		PartBPrompt: "Find the smallest directory that, if deleted, would free up enough space. What is the total size of that directory?",
+		PartBParams: []*Param{&day7DiskSize, &day7SpaceNeeded},
+		Solver:      Day7Solver{},
+	})
+}
+
+var day7DiskSize = Param{Name: "Disk size", Description: "Total space on the filesystem", Default: 70000000}
+
+var day7SpaceNeeded = Param{Name: "Space needed", Description: "Free space the update needs", Default: 30000000}
+
 type Day7Solver struct {
 }
 
-func (d Day7Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day7Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	root, err := buildDirectoryTree(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	fixDirectorySizes(root)
 	totalSize := sumDirectorySizeIf(root, func(d *directoryTreeItem) bool {
 		return d.isDirectory && d.cumulativeSize < 100000
 	})
 	// TODO: make tree view bigger, probably requires changing the layout in the main app :(
-	ft := directoryTreeToFyneTree(root)
-	return strconv.Itoa(totalSize), ft, nil
+	ft := func() (Visual, error) { return directoryTreeToVisual(root), nil }
+	return Result{Answer: strconv.Itoa(totalSize), Visual: ft}, nil
 }
 
-func (d Day7Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day7Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	root, err := buildDirectoryTree(puzzleInput)
 	if err != nil {
-		return "", nil, err
+		return Result{}, err
 	}
 	fixDirectorySizes(root)
 
-	freeSpace := 70000000 - root.cumulativeSize
-	requiredSize := 30000000 - freeSpace
+	freeSpace := int(day7DiskSize.Get(params)) - root.cumulativeSize
+	requiredSize := int(day7SpaceNeeded.Get(params)) - freeSpace
 
 	directoryName, directorySize := findSmallestDirectoryBiggerThan(root, requiredSize)
 
-	return strconv.Itoa(directorySize), widget.NewLabel("Smallest directory that's big enough: " + directoryName), nil
+	return Result{
+		Answer: strconv.Itoa(directorySize),
+		Facts:  []Fact{{Name: "Smallest directory that's big enough", Value: directoryName}},
+	}, nil
 }
 
 type directoryTreeItem struct {
@@ -82,13 +100,13 @@ func buildDirectoryTree(input string) (*directoryTreeItem, error) {
 
 	for i := 0; i < len(cmds); i++ {
 		if cmds[i][0] != "$" {
-			return breadcrumbs[0], errors.New("Got data line when command was expected, line = " + commands[i])
+			return nil, lineError(i, commands[i], "Got data line when command was expected")
 		}
 		switch cmds[i][1] {
 		case "cd":
 			if cmds[i][2] == ".." {
 				if len(breadcrumbs) <= 1 {
-					return breadcrumbs[0], errors.New("attempted to change directory up past root")
+					return nil, lineError(i, commands[i], "attempted to change directory up past root").at("..")
 				}
 				breadcrumbs = breadcrumbs[:len(breadcrumbs)-1]
 			} else {
@@ -110,7 +128,7 @@ func buildDirectoryTree(input string) (*directoryTreeItem, error) {
 					for k := range breadcrumbs[len(breadcrumbs)-1].children {
 						errorMsg.WriteString(k + ", ")
 					}
-					return breadcrumbs[0], errors.New(errorMsg.String())
+					return nil, lineError(i, commands[i], errorMsg.String()).atIndex(strings.LastIndex(commands[i], cmds[i][2]))
 				}
 
 				breadcrumbs = append(breadcrumbs, child)
@@ -130,9 +148,9 @@ func buildDirectoryTree(input string) (*directoryTreeItem, error) {
 						children:       make(map[string]*directoryTreeItem),
 					}
 				} else {
-					size, err := strconv.Atoi(cmds[i][0])
+					size, err := atoiField(i, commands[i], cmds[i][0])
 					if err != nil {
-						return breadcrumbs[0], errors.New("Failed to convert size to int in line " + commands[i])
+						return nil, err
 					}
 					breadcrumbs[len(breadcrumbs)-1].children[cmds[i][1]] = &directoryTreeItem{
 						name:           cmds[i][1],
@@ -143,7 +161,7 @@ func buildDirectoryTree(input string) (*directoryTreeItem, error) {
 			}
 
 		default:
-			return breadcrumbs[0], errors.New("Failed to parse command in line: " + commands[i])
+			return nil, lineError(i, commands[i], "Failed to parse command").at(cmds[i][1])
 		}
 	}
 	return breadcrumbs[0], nil
@@ -161,13 +179,13 @@ func fixDirectorySizes(currentNode *directoryTreeItem) {
 	currentNode.cumulativeSize = total
 }
 
-func directoryTreeToFyneTree(dt *directoryTreeItem) *widget.Tree {
+func directoryTreeToVisual(dt *directoryTreeItem) TreeVisual {
 	ft := make(map[string][]string, 100)
 	root := make([]string, 1)
 	root[0] = dt.fyneName()
 	ft[""] = root
 	descendTree(dt, ft)
-	return widget.NewTreeWithStrings(ft)
+	return TreeVisual{Children: ft}
 }
 
 func descendTree(currentNode *directoryTreeItem, mapSoFar map[string][]string) {
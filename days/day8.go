@@ -1,20 +1,28 @@
 package days
 
 import (
-	"image/color"
+	"context"
 	"strconv"
 	"strings"
-
-	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/layout"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      8,
+		Title:       "Treetop Tree House",
+		PartATests:  day8TestsPartA,
+		PartBTests:  day8TestsPartB,
+		PartAPrompt: "How many trees are visible from outside the grid?",
+		PartBPrompt: "What is the highest scenic score possible for any tree?",
+		Solver:      Day8Solver{},
+	})
+}
+
 type Day8Solver struct {
 }
 
-func (d Day8Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day8Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	tg := buildTreeGrid(puzzleInput)
 	visible := buildTreeVisibilityGrid(tg)
 	visibleCount := 0
@@ -26,12 +34,10 @@ func (d Day8Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, e
 		}
 	}
 
-	content, err := visualizeTreeVisibility(tg, visible)
-
-	return strconv.Itoa(visibleCount), content, err
+	return Result{Answer: strconv.Itoa(visibleCount), Visual: visualizeTreeVisibility(tg, visible)}, nil
 }
 
-func (d Day8Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day8Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	tg := buildTreeGrid(puzzleInput)
 	x, y, score := findBestScenicScore(tg)
 
@@ -42,9 +48,7 @@ func (d Day8Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, e
 
 	highlight[y][x] = true
 
-	content, err := visualizeTreeVisibility(tg, highlight)
-
-	return strconv.Itoa(score), content, err
+	return Result{Answer: strconv.Itoa(score), Visual: visualizeTreeVisibility(tg, highlight)}, nil
 }
 
 type treeGrid [][]int
@@ -133,20 +137,18 @@ func buildTreeVisibilityGrid(tg treeGrid) [][]bool {
 	return visible
 }
 
-func visualizeTreeVisibility(tg treeGrid, visible [][]bool) (fyne.CanvasObject, error) {
-	grid := container.New(layout.NewGridLayout(len(tg[0])))
-	for y := 0; y < len(tg); y++ {
-		for x := 0; x < len(tg[0]); x++ {
-			if visible[y][x] {
-				grid.Add(canvas.NewText(strconv.Itoa(tg[y][x]), color.RGBA{255, 0, 0, 255}))
-			} else {
-				grid.Add(canvas.NewText(strconv.Itoa(tg[y][x]), color.Black))
+func visualizeTreeVisibility(tg treeGrid, visible [][]bool) Visualizer {
+	return func() (Visual, error) {
+		cells := make([][]string, len(tg))
+		for y := 0; y < len(tg); y++ {
+			cells[y] = make([]string, len(tg[0]))
+			for x := 0; x < len(tg[0]); x++ {
+				cells[y][x] = strconv.Itoa(tg[y][x])
 			}
-
 		}
-	}
 
-	return container.NewHScroll(grid), nil
+		return GridVisual{Cells: cells, Highlight: visible}, nil
+	}
 }
 
 func findBestScenicScore(tg treeGrid) (int, int, int) {
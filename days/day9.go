@@ -1,21 +1,34 @@
 package days
 
 import (
+	"context"
 	"errors"
 	"image/color"
 	"math"
 	"strconv"
 	"strings"
 
-	"fyne.io/fyne/v2"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 )
 
+func init() {
+	Register(Day{
+		Year:        2022,
+		Number:      9,
+		Title:       "Rope Bridge",
+		PartATests:  day9TestsPartA,
+		PartBTests:  day9TestsPartB,
+		PartAPrompt: "Simulate your complete hypothetical series of motions. How many positions does the tail of the rope visit at least once?",
+		PartBPrompt: "Simulate your complete series of motions on a larger rope with ten knots. How many positions does the tail of the rope visit at least once?",
+		Solver:      Day9Solver{},
+	})
+}
+
 type Day9Solver struct {
 }
 
-func (d Day9Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day9Solver) SolvePartA(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	commands := strings.Split(strings.TrimSpace(puzzleInput), "\n")
 
 	r := ropeState{}
@@ -26,18 +39,18 @@ func (d Day9Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, e
 
 	tailPosSet := make(map[point]bool)
 
-	for _, c := range commands {
+	for i, c := range commands {
 		parts := strings.Split(c, " ")
 		if len(parts) != 2 {
-			return "", nil, errors.New("failed to parse line: " + c)
+			return Result{}, lineError(i, c, "expected a direction and a number of steps")
 		}
-		steps, err := strconv.Atoi(parts[1])
+		steps, err := atoiField(i, c, parts[1])
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
 		for s := 0; s < steps; s++ {
 			if err := r.MoveHead(parts[0]); err != nil {
-				return "", nil, err
+				return Result{}, lineError(i, c, "can't move").atIndex(0).wrap(err)
 			}
 			headHistory = append(headHistory, plotter.XY{X: float64(r.head.x), Y: float64(r.head.y)})
 			tailHistory = append(tailHistory, plotter.XY{X: float64(r.tail.x), Y: float64(r.tail.y)})
@@ -45,32 +58,33 @@ func (d Day9Solver) SolvePartA(puzzleInput string) (string, fyne.CanvasObject, e
 		}
 	}
 
-	plt := plot.New()
-	plt.Title.Text = "Rope Position"
-	plt.X.Label.Text = "X"
-	plt.Y.Label.Text = "Y"
-
-	l0, err := plotter.NewLine(headHistory)
-	if err != nil {
-		return strconv.Itoa(len(tailPosSet)), nil, err
-	}
-	l0.Color = color.RGBA{R: 255, A: 255}
-	l1, err := plotter.NewLine(tailHistory)
-	if err != nil {
-		return strconv.Itoa(len(tailPosSet)), nil, err
-	}
-	l1.Color = color.RGBA{B: 255, A: 255}
+	img := func() (Visual, error) {
+		plt := plot.New()
+		plt.Title.Text = "Rope Position"
+		plt.X.Label.Text = "X"
+		plt.Y.Label.Text = "Y"
 
-	plt.Add(l0)
-	plt.Add(l1)
-	plt.Legend.Add("Head", l0)
-	plt.Legend.Add("Tail", l1)
+		l0, err := plotter.NewLine(headHistory)
+		if err != nil {
+			return nil, err
+		}
+		l0.Color = color.RGBA{R: 255, A: 255}
+		l1, err := plotter.NewLine(tailHistory)
+		if err != nil {
+			return nil, err
+		}
+		l1.Color = color.RGBA{B: 255, A: 255}
 
-	img, err := plotToImage(plt, "day9partA.png")
-	return strconv.Itoa(len(tailPosSet)), img, err
+		plt.Add(l0)
+		plt.Add(l1)
+		plt.Legend.Add("Head", l0)
+		plt.Legend.Add("Tail", l1)
+		return PlotVisual{Plot: plt, Name: "day9partA.png"}, nil
+	}
+	return Result{Answer: strconv.Itoa(len(tailPosSet)), Visual: img}, nil
 }
 
-func (d Day9Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, error) {
+func (d Day9Solver) SolvePartB(ctx context.Context, puzzleInput string, params Params) (Result, error) {
 	commands := strings.Split(strings.TrimSpace(puzzleInput), "\n")
 
 	r := longRopeState{}
@@ -79,18 +93,18 @@ func (d Day9Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, e
 	tailHistory := make(plotter.XYs, 0, len(commands)*2)
 	tailHistory = append(tailHistory, plotter.XY{X: 0, Y: 0})
 
-	for _, c := range commands {
+	for i, c := range commands {
 		parts := strings.Split(c, " ")
 		if len(parts) != 2 {
-			return "", nil, errors.New("failed to parse line: " + c)
+			return Result{}, lineError(i, c, "expected a direction and a number of steps")
 		}
-		steps, err := strconv.Atoi(parts[1])
+		steps, err := atoiField(i, c, parts[1])
 		if err != nil {
-			return "", nil, err
+			return Result{}, err
 		}
 		for s := 0; s < steps; s++ {
 			if err := r.MoveHead(parts[0]); err != nil {
-				return "", nil, err
+				return Result{}, lineError(i, c, "can't move").atIndex(0).wrap(err)
 			}
 			tail := r.knots[len(r.knots)-1]
 			tailPosSet[tail] = true
@@ -98,33 +112,35 @@ func (d Day9Solver) SolvePartB(puzzleInput string) (string, fyne.CanvasObject, e
 		}
 	}
 
-	finalPosition := make(plotter.XYs, len(r.knots))
-	for i, k := range r.knots {
-		finalPosition[i].X = float64(k.x)
-		finalPosition[i].Y = float64(k.y)
-	}
-	l0, err := plotter.NewLine(finalPosition)
-	if err != nil {
-		return strconv.Itoa(len(tailPosSet)), nil, err
-	}
+	img := func() (Visual, error) {
+		finalPosition := make(plotter.XYs, len(r.knots))
+		for i, k := range r.knots {
+			finalPosition[i].X = float64(k.x)
+			finalPosition[i].Y = float64(k.y)
+		}
+		l0, err := plotter.NewLine(finalPosition)
+		if err != nil {
+			return nil, err
+		}
 
-	l1, err := plotter.NewLine(tailHistory)
-	if err != nil {
-		return strconv.Itoa(len(tailPosSet)), nil, err
+		l1, err := plotter.NewLine(tailHistory)
+		if err != nil {
+			return nil, err
+		}
+		l1.Color = color.RGBA{B: 255, A: 255}
+
+		plt := plot.New()
+		plt.Title.Text = "Final Rope Position"
+		plt.X.Label.Text = "X"
+		plt.Y.Label.Text = "Y"
+
+		plt.Add(l0)
+		plt.Add(l1)
+		plt.Legend.Add("Final Position", l0)
+		plt.Legend.Add("Tail Path", l1)
+		return PlotVisual{Plot: plt, Name: "day9partB.png"}, nil
 	}
-	l1.Color = color.RGBA{B: 255, A: 255}
-
-	plt := plot.New()
-	plt.Title.Text = "Final Rope Position"
-	plt.X.Label.Text = "X"
-	plt.Y.Label.Text = "Y"
-
-	plt.Add(l0)
-	plt.Add(l1)
-	plt.Legend.Add("Final Position", l0)
-	plt.Legend.Add("Tail Path", l1)
-	img, err := plotToImage(plt, "day9partB.png")
-	return strconv.Itoa(len(tailPosSet)), img, err
+	return Result{Answer: strconv.Itoa(len(tailPosSet)), Visual: img}, nil
 }
 
 // Up, right = positive, down, left = negative
@@ -0,0 +1,184 @@
+package days_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"example.com/advent2022/days"
+)
+
+// Solves that take longer than this fail, so a solver that hangs or gets a lot
+// slower is caught
+var solveTimeout = flag.Duration("solve-timeout", time.Minute, "fail solves that take longer than this, 0 for no limit")
+
+// Slow parts (day 19's take minutes) are skipped unless this is set, and aren't
+// given a time limit when they're run
+var runSlow = flag.Bool("slow", false, "also run the example tests marked as slow")
+
+// With -short each solve gets at most this long
+const shortTimeout = 10 * time.Second
+
+// Runs every example test of every day and part, then the puzzle input if
+// there is one, checking it against the recorded answer. Parts that aren't
+// implemented are skipped rather than passed, as are slow parts without
+// -args -slow.
+func TestDays(t *testing.T) {
+	answersDir := days.DefaultAnswersDir()
+	answers, missing, err := days.LoadAnswers(answersDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, year := range missing {
+		t.Logf("no recorded answers for %d at %s, its puzzle solutions aren't checked", year, days.AnswersPath(answersDir, year))
+	}
+	for _, d := range days.All() {
+		d := d
+		t.Run(fmt.Sprintf("%d/day%02d", d.Year, d.Number), func(t *testing.T) {
+			for _, p := range []days.Part{days.PartA, days.PartB} {
+				p := p
+				t.Run(p.String(), func(t *testing.T) {
+					if !d.Implemented(p) {
+						t.Skip("not implemented")
+					}
+					timeout := testTimeout()
+					if d.Slow(p) {
+						if !*runSlow {
+							t.Skip("slow, use -args -slow to run it")
+						}
+						timeout = 0
+					}
+					for i, test := range d.Tests(p) {
+						test := test
+						t.Run(fmt.Sprint("example", i), func(t *testing.T) {
+							actual := solveForTest(t, d, p, test.Input, test.Params, timeout)
+							if actual != test.ExpectedOutput {
+								t.Errorf("got %q, expected %q", actual, test.ExpectedOutput)
+							}
+						})
+					}
+					t.Run("puzzle", func(t *testing.T) {
+						actual := solveForTest(t, d, p, puzzleInput(t, d), nil, timeout)
+						if recorded, ok := answers.Get(d, p); ok && actual != recorded {
+							t.Errorf("got %q, recorded answer is %q", actual, recorded)
+						}
+					})
+				})
+			}
+		})
+	}
+}
+
+// How long each solve gets, 0 for no limit
+func testTimeout() time.Duration {
+	timeout := *solveTimeout
+	if testing.Short() && (timeout == 0 || timeout > shortTimeout) {
+		timeout = shortTimeout
+	}
+	return timeout
+}
+
+// Solves the input with params, failing the test on an error, an empty answer
+// or taking longer than timeout (0 for no limit), and skipping it if the part
+// isn't implemented
+func solveForTest(t *testing.T, d days.Day, p days.Part, input string, params days.Params, timeout time.Duration) string {
+	t.Helper()
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	res, err := d.Solve(ctx, p, input, params)
+	if errors.Is(err, days.ErrCancelled) {
+		t.Fatal("took longer than", timeout)
+	}
+	if errors.Is(err, days.ErrNotImplemented) {
+		t.Skip("not implemented")
+	}
+	if err != nil {
+		t.Fatal("returned err:", err, "\n"+panicStack(err))
+	}
+	if res.Answer == "" {
+		t.Fatal("returned an empty answer")
+	}
+	if _, err := strconv.Atoi(res.Answer); res.Kind == days.IntegerAnswer && err != nil {
+		t.Errorf("answer %q is meant to be an integer", res.Answer)
+	}
+	return res.Answer
+}
+
+// Loads the day's puzzle input from the default directory, skipping the test if it's not there
+func puzzleInput(t *testing.T, d days.Day) string {
+	t.Helper()
+	input, err := d.LoadPuzzleInput(days.DefaultInputDir())
+	if errors.Is(err, days.ErrInputMissing) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input
+}
+
+// Benchmarks every example and puzzle input of every day and part, ex:
+// go test ./days -run '^$' -bench 'Days/2022/day12/B' -benchmem
+// Unimplemented parts and missing inputs are skipped.
+func BenchmarkDays(b *testing.B) {
+	for _, d := range days.All() {
+		d := d
+		b.Run(fmt.Sprintf("%d/day%02d", d.Year, d.Number), func(b *testing.B) {
+			for _, p := range []days.Part{days.PartA, days.PartB} {
+				p := p
+				b.Run(p.String(), func(b *testing.B) {
+					if !d.Implemented(p) {
+						b.Skip("not implemented")
+					}
+					for i, test := range d.Tests(p) {
+						test := test
+						b.Run(fmt.Sprint("example", i), func(b *testing.B) {
+							benchmarkSolve(b, d, p, test.Input, test.Params)
+						})
+					}
+					b.Run("puzzle", func(b *testing.B) {
+						input, err := d.LoadPuzzleInput(days.DefaultInputDir())
+						if errors.Is(err, days.ErrInputMissing) {
+							b.Skip(err)
+						}
+						if err != nil {
+							b.Fatal(err)
+						}
+						benchmarkSolve(b, d, p, input, nil)
+					})
+				})
+			}
+		})
+	}
+}
+
+func benchmarkSolve(b *testing.B, d days.Day, p days.Part, input string, params days.Params) {
+	b.ReportAllocs()
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		_, err := d.Solve(ctx, p, input, params)
+		if errors.Is(err, days.ErrNotImplemented) {
+			b.Skip("not implemented")
+		}
+		if err != nil {
+			b.Fatal("returned err:", err, "\n"+panicStack(err))
+		}
+	}
+}
+
+// Stack trace of the panic behind err, empty if it wasn't one
+func panicStack(err error) string {
+	var panicErr *days.PanicError
+	if errors.As(err, &panicErr) {
+		return string(panicErr.Stack)
+	}
+	return ""
+}
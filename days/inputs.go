@@ -0,0 +1,52 @@
+package days
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrInputMissing is wrapped by LoadPuzzleInput's error when there's no input file for the day
+var ErrInputMissing = errors.New("puzzle input missing")
+
+// DefaultInputDir is where puzzle inputs live unless told otherwise:
+// $XDG_CONFIG_HOME/advent2022/inputs (or the OS equivalent), falling back to
+// ./inputs if there's no config directory. Inputs for each event go in a
+// folder named for its year, ex: inputs/2022/day1.txt
+func DefaultInputDir() string {
+	config, err := os.UserConfigDir()
+	if err != nil {
+		return "inputs"
+	}
+	return filepath.Join(config, "advent2022", "inputs")
+}
+
+// Path of the file holding the day's puzzle input within dir
+func (d Day) InputPath(dir string) string {
+	return filepath.Join(dir, strconv.Itoa(d.Year), "day"+strconv.Itoa(d.Number)+".txt")
+}
+
+// Reads the day's puzzle input from dir. The error wraps ErrInputMissing if
+// the file doesn't exist.
+func (d Day) LoadPuzzleInput(dir string) (string, error) {
+	path := d.InputPath(dir)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w, expected it at %s", ErrInputMissing, path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return NormalizeInput(string(b)), nil
+}
+
+// Converts Windows line endings so solvers only have to split on "\n", and
+// drops the newline files usually end with. Solvers would otherwise see an
+// extra empty line and fail to parse it.
+func NormalizeInput(input string) string {
+	return strings.TrimRight(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,66 @@
+package days
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned by Day.Solve (and Day.Guard) in place of a panic from
+// the solver, so one bad input can't take down the whole program. Panics in goroutines the
+// solver starts itself aren't caught.
+type PanicError struct {
+	Day  Key
+	Part Part
+	// Whatever was passed to panic
+	Value interface{}
+	// Stack of the panicking goroutine, as printed by debug.Stack
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s part %s panicked: %v", e.Day, e.Part, e.Value)
+}
+
+// Panics with an error, such as a runtime error for an index out of range,
+// can be checked with errors.As
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Calls the part's solver, turning a panic into a *PanicError
+func (d Day) callSolver(ctx context.Context, p Part, input string, params Params) (res Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = Result{}
+			err = &PanicError{Day: d.Key(), Part: p, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	if p == PartB {
+		return d.Solver.SolvePartB(ctx, input, params)
+	}
+	return d.Solver.SolvePartA(ctx, input, params)
+}
+
+// Guard calls f, turning a panic into a *PanicError for the part the same way
+// Solve does. A Result's Visualizer and the frames of any PlaybackVisual it
+// builds run the day's code after Solve has returned, so whatever builds or
+// draws them should go through here.
+func (d Day) Guard(p Part, f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Day: d.Key(), Part: p, Value: r, Stack: debug.Stack()}
+		}
+	}()
+	f()
+	return nil
+}
+
+// Builds the part's Visual with Guard
+func (d Day) BuildVisual(p Part, v Visualizer) (vis Visual, err error) {
+	if panicErr := d.Guard(p, func() { vis, err = v.Build() }); panicErr != nil {
+		return nil, panicErr
+	}
+	return vis, err
+}
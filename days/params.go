@@ -0,0 +1,48 @@
+package days
+
+import (
+	"fmt"
+)
+
+// Param is a number a solver reads rather than hard coding, like which row to
+// look at or how many rounds to run. Default is what the puzzle input is meant
+// to be solved with, examples often need something smaller and set it in
+// SinglePartTest.Params. Values are 64 bit so counts like day 17's trillion
+// rocks fit on any platform.
+type Param struct {
+	Name        string
+	Description string
+	Default     int64
+}
+
+// Params holds values for a part's Params, keyed by the Param itself. Any left
+// out use their default.
+type Params map[*Param]int64
+
+// Value of the param in params, or its default if it isn't set
+func (p *Param) Get(params Params) int64 {
+	if v, ok := params[p]; ok {
+		return v
+	}
+	return p.Default
+}
+
+// Makes sure every value in params is for one of the part's Params, so a value
+// meant for another part or day isn't silently ignored
+func (d Day) checkParams(p Part, params Params) error {
+	for param := range params {
+		if !d.hasParam(p, param) {
+			return fmt.Errorf("%s part %s has no parameter %q", d.Key(), p, param.Name)
+		}
+	}
+	return nil
+}
+
+func (d Day) hasParam(p Part, param *Param) bool {
+	for _, q := range d.Params(p) {
+		if q == param {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package days
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseError points at the part of an input that couldn't be understood
+type ParseError struct {
+	// Filled in by Day.Solve, parsers don't need to know which day they're for
+	Day Key
+	// 1 based, as shown in an editor
+	Line int
+	// 1 based, 0 if the whole line is at fault
+	Column int
+	// The offending line
+	Text string
+	Msg  string
+	// What went wrong underneath, ex: the error from strconv. Can be nil
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.Day != (Key{}) {
+		sb.WriteString(e.Day.String() + " ")
+	}
+	sb.WriteString("line " + strconv.Itoa(e.Line))
+	if e.Column > 0 {
+		sb.WriteString(", column " + strconv.Itoa(e.Column))
+	}
+	sb.WriteString(": " + e.Msg)
+	if e.Err != nil {
+		sb.WriteString(": " + e.Err.Error())
+	}
+	sb.WriteString(" in " + strconv.Quote(e.Text))
+	return sb.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Error for line i of an input. i is zero based, as when ranging over
+// strings.Split(input, "\n").
+func lineError(i int, line, msg string) *ParseError {
+	return &ParseError{Line: i + 1, Text: line, Msg: msg}
+}
+
+// Points the error at the first place field appears in the line
+func (e *ParseError) at(field string) *ParseError {
+	if idx := strings.Index(e.Text, field); idx >= 0 && field != "" {
+		e.Column = idx + 1
+	}
+	return e
+}
+
+// Points the error at a zero based index into the line
+func (e *ParseError) atIndex(idx int) *ParseError {
+	e.Column = idx + 1
+	return e
+}
+
+func (e *ParseError) wrap(err error) *ParseError {
+	e.Err = err
+	return e
+}
+
+// strconv.Atoi for a field of line i, failures point at the field
+func atoiField(i int, line, field string) (int, error) {
+	v, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, lineError(i, line, "expected a number").at(field).wrap(err)
+	}
+	return v, nil
+}
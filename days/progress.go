@@ -0,0 +1,36 @@
+package days
+
+import "context"
+
+// Progress is a snapshot of how far along a solve is. Fraction is between 0
+// and 1, or negative if the solver can't tell how much work is left (such as
+// a search that hasn't finished). Counters hold whatever the solver thinks is
+// worth watching, like nodes explored or rounds completed.
+type Progress struct {
+	Fraction float64
+	Counters []Counter
+}
+
+type Counter struct {
+	Name  string
+	Value int
+}
+
+// ProgressFunc receives progress updates on the solver's goroutine, so it
+// should return quickly
+type ProgressFunc func(Progress)
+
+type progressKey struct{}
+
+// WithProgress returns a context that passes any progress reported by a
+// solver on to report
+func WithProgress(ctx context.Context, report ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, report)
+}
+
+// Sends progress to the ProgressFunc attached to ctx, does nothing if there isn't one
+func reportProgress(ctx context.Context, fraction float64, counters ...Counter) {
+	if report, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		report(Progress{Fraction: fraction, Counters: counters})
+	}
+}
@@ -1,78 +0,0 @@
-package days
-
-import _ "embed"
-
-//go:embed puzzleInputs/day1.txt
-var day1PuzzleInput string
-
-//go:embed puzzleInputs/day2.txt
-var day2PuzzleInput string
-
-//go:embed puzzleInputs/day3.txt
-var day3PuzzleInput string
-
-//go:embed puzzleInputs/day4.txt
-var day4PuzzleInput string
-
-//go:embed puzzleInputs/day5.txt
-var day5PuzzleInput string
-
-//go:embed puzzleInputs/day6.txt
-var day6PuzzleInput string
-
-//go:embed puzzleInputs/day7.txt
-var day7PuzzleInput string
-
-//go:embed puzzleInputs/day8.txt
-var day8PuzzleInput string
-
-//go:embed puzzleInputs/day9.txt
-var day9PuzzleInput string
-
-//go:embed puzzleInputs/day10.txt
-var day10PuzzleInput string
-
-//go:embed puzzleInputs/day11.txt
-var day11PuzzleInput string
-
-//go:embed puzzleInputs/day12.txt
-var day12PuzzleInput string
-
-//go:embed puzzleInputs/day13.txt
-var day13PuzzleInput string
-
-//go:embed puzzleInputs/day14.txt
-var day14PuzzleInput string
-
-//go:embed puzzleInputs/day15.txt
-var day15PuzzleInput string
-
-//go:embed puzzleInputs/day16.txt
-var day16PuzzleInput string
-
-//go:embed puzzleInputs/day17.txt
-var day17PuzzleInput string
-
-//go:embed puzzleInputs/day18.txt
-var day18PuzzleInput string
-
-//go:embed puzzleInputs/day19.txt
-var day19PuzzleInput string
-
-//go:embed puzzleInputs/day20.txt
-var day20PuzzleInput string
-
-//go:embed puzzleInputs/day21.txt
-var day21PuzzleInput string
-
-//go:embed puzzleInputs/day22.txt
-var day22PuzzleInput string
-
-//go:embed puzzleInputs/day23.txt
-var day23PuzzleInput string
-
-//go:embed puzzleInputs/day24.txt
-var day24PuzzleInput string
-
-//go:embed puzzleInputs/day25.txt
-var day25PuzzleInput string
@@ -0,0 +1,75 @@
+package days
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Key identifies a day across events
+type Key struct {
+	Year, Number int
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%d day %d", k.Year, k.Number)
+}
+
+// Every registered day, see Register
+var registry = make(map[Key]Day)
+
+// Register adds a day so the GUI, CLI and tests can find it. Each day calls
+// this from an init function in its own file, so adding a day (or a whole
+// event in another package) doesn't mean editing a central list. Registering
+// the same year and day twice panics, as does leaving either out.
+func Register(d Day) {
+	if d.Year == 0 || d.Number == 0 {
+		panic(fmt.Sprintf("days: registering day without a year and number: %+v", d.Key()))
+	}
+	if _, ok := registry[d.Key()]; ok {
+		panic("days: " + d.Key().String() + " registered twice")
+	}
+	registry[d.Key()] = d
+}
+
+// Lookup finds a registered day by its year and number
+func Lookup(year, number int) (Day, bool) {
+	d, ok := registry[Key{year, number}]
+	return d, ok
+}
+
+// All returns every registered day, ordered by year then day number
+func All() []Day {
+	all := make([]Day, 0, len(registry))
+	for _, d := range registry {
+		all = append(all, d)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Year != all[j].Year {
+			return all[i].Year < all[j].Year
+		}
+		return all[i].Number < all[j].Number
+	})
+	return all
+}
+
+// Years returns the years with at least one registered day, in order
+func Years() []int {
+	years := make([]int, 0, 1)
+	for _, d := range All() {
+		if len(years) == 0 || years[len(years)-1] != d.Year {
+			years = append(years, d.Year)
+		}
+	}
+	return years
+}
+
+// InYear returns the year's registered days in order
+func InYear(year int) []Day {
+	var inYear []Day
+	for _, d := range All() {
+		if d.Year == year {
+			inYear = append(inYear, d)
+		}
+	}
+	return inYear
+}
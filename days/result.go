@@ -0,0 +1,72 @@
+package days
+
+import "time"
+
+// Result is everything a solver found out about its input
+type Result struct {
+	// The puzzle answer, compared against SinglePartTest.ExpectedOutput.
+	// Left empty by parts that aren't implemented yet.
+	Answer string
+	Kind   AnswerKind
+	// Things found along the way that are worth showing next to the answer
+	Facts []Fact
+	// Optional, builds the Visual showing the solution
+	Visual Visualizer
+	// How long the solve took. Solvers can add their own phases, Day.Solve
+	// always adds the total.
+	Timings []Timing
+	// Anything else the solver produced that's worth keeping, such as extra
+	// visualizations
+	Artifacts []Artifact
+}
+
+// AnswerKind says how an answer should be shown and compared
+type AnswerKind int
+
+const (
+	IntegerAnswer AnswerKind = iota
+	TextAnswer
+	// The answer has to be read from a picture, such as letters drawn on a
+	// screen. Answer holds the picture as text so it can still be compared.
+	ImageOnlyAnswer
+)
+
+func (k AnswerKind) String() string {
+	switch k {
+	case TextAnswer:
+		return "text"
+	case ImageOnlyAnswer:
+		return "image"
+	default:
+		return "integer"
+	}
+}
+
+// Fact is a labelled piece of information, ex: the directory chosen in day 7
+type Fact struct {
+	Name  string
+	Value string
+}
+
+type Timing struct {
+	Name     string
+	Duration time.Duration
+}
+
+type Artifact struct {
+	Name   string
+	Visual Visualizer
+}
+
+// Name of the Timing Day.Solve adds for the whole solve
+const TotalTiming = "Total"
+
+// Duration of the named timing, zero if there isn't one
+func (r Result) Timing(name string) time.Duration {
+	for _, t := range r.Timings {
+		if t.Name == name {
+			return t.Duration
+		}
+	}
+	return 0
+}
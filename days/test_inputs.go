@@ -1,6 +1,6 @@
 package days
 
-var day1TestsPartA = []SinglePartTest{{`1000
+var day1TestsPartA = []SinglePartTest{{Input: `1000
 2000
 3000
 
@@ -13,9 +13,9 @@ var day1TestsPartA = []SinglePartTest{{`1000
 8000
 9000
 
-10000`, "24000"}}
+10000`, ExpectedOutput: "24000"}}
 
-var day1TestsPartB = []SinglePartTest{{`1000
+var day1TestsPartB = []SinglePartTest{{Input: `1000
 2000
 3000
 
@@ -28,45 +28,45 @@ var day1TestsPartB = []SinglePartTest{{`1000
 8000
 9000
 
-10000`, "45000"}}
+10000`, ExpectedOutput: "45000"}}
 
-var day2TestsPartA = []SinglePartTest{{`A Y
+var day2TestsPartA = []SinglePartTest{{Input: `A Y
 B X
-C Z`, "15"}}
+C Z`, ExpectedOutput: "15"}}
 
-var day2TestsPartB = []SinglePartTest{{`A Y
+var day2TestsPartB = []SinglePartTest{{Input: `A Y
 B X
-C Z`, "12"}}
+C Z`, ExpectedOutput: "12"}}
 
-var day3TestsPartA = []SinglePartTest{{`vJrwpWtwJgWrhcsFMMfFFhFp
+var day3TestsPartA = []SinglePartTest{{Input: `vJrwpWtwJgWrhcsFMMfFFhFp
 jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL
 PmmdzqPrVvPwwTWBwg
 wMqvLMZHhHMvwLHjbvcjnnSBnvTQFn
 ttgJtRGJQctTZtZT
-CrZsJsPPZsGzwwsLwLmpwMDw`, "157"}}
+CrZsJsPPZsGzwwsLwLmpwMDw`, ExpectedOutput: "157"}}
 
-var day3TestsPartB = []SinglePartTest{{`vJrwpWtwJgWrhcsFMMfFFhFp
+var day3TestsPartB = []SinglePartTest{{Input: `vJrwpWtwJgWrhcsFMMfFFhFp
 jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL
 PmmdzqPrVvPwwTWBwg
 wMqvLMZHhHMvwLHjbvcjnnSBnvTQFn
 ttgJtRGJQctTZtZT
-CrZsJsPPZsGzwwsLwLmpwMDw`, "70"}}
+CrZsJsPPZsGzwwsLwLmpwMDw`, ExpectedOutput: "70"}}
 
-var day4TestsPartA = []SinglePartTest{{`2-4,6-8
+var day4TestsPartA = []SinglePartTest{{Input: `2-4,6-8
 2-3,4-5
 5-7,7-9
 2-8,3-7
 6-6,4-6
-2-6,4-8`, "2"}}
+2-6,4-8`, ExpectedOutput: "2"}}
 
-var day4TestsPartB = []SinglePartTest{{`2-4,6-8
+var day4TestsPartB = []SinglePartTest{{Input: `2-4,6-8
 2-3,4-5
 5-7,7-9
 2-8,3-7
 6-6,4-6
-2-6,4-8`, "4"}}
+2-6,4-8`, ExpectedOutput: "4"}}
 
-var day5TestsPartA = []SinglePartTest{{`    [D]    
+var day5TestsPartA = []SinglePartTest{{Input: `    [D]    
 [N] [C]    
 [Z] [M] [P]
  1   2   3 
@@ -74,9 +74,9 @@ var day5TestsPartA = []SinglePartTest{{`    [D]
 move 1 from 2 to 1
 move 3 from 1 to 3
 move 2 from 2 to 1
-move 1 from 1 to 2`, "CMZ"}}
+move 1 from 1 to 2`, ExpectedOutput: "CMZ"}}
 
-var day5TestsPartB = []SinglePartTest{{`    [D]    
+var day5TestsPartB = []SinglePartTest{{Input: `    [D]    
 [N] [C]    
 [Z] [M] [P]
  1   2   3 
@@ -84,23 +84,23 @@ var day5TestsPartB = []SinglePartTest{{`    [D]
 move 1 from 2 to 1
 move 3 from 1 to 3
 move 2 from 2 to 1
-move 1 from 1 to 2`, "MCD"}}
+move 1 from 1 to 2`, ExpectedOutput: "MCD"}}
 
 var day6TestsPartA = []SinglePartTest{
-	{"mjqjpqmgbljsphdztnvjfqwrcgsmlb", "7"},
-	{"bvwbjplbgvbhsrlpgdmjqwftvncz", "5"},
-	{"nppdvjthqldpwncqszvftbrmjlhg", "6"},
-	{"nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", "10"},
-	{"zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", "11"}}
+	{Input: "mjqjpqmgbljsphdztnvjfqwrcgsmlb", ExpectedOutput: "7"},
+	{Input: "bvwbjplbgvbhsrlpgdmjqwftvncz", ExpectedOutput: "5"},
+	{Input: "nppdvjthqldpwncqszvftbrmjlhg", ExpectedOutput: "6"},
+	{Input: "nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", ExpectedOutput: "10"},
+	{Input: "zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", ExpectedOutput: "11"}}
 
 var day6TestsPartB = []SinglePartTest{
-	{"mjqjpqmgbljsphdztnvjfqwrcgsmlb", "19"},
-	{"bvwbjplbgvbhsrlpgdmjqwftvncz", "23"},
-	{"nppdvjthqldpwncqszvftbrmjlhg", "23"},
-	{"nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", "29"},
-	{"zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", "26"}}
+	{Input: "mjqjpqmgbljsphdztnvjfqwrcgsmlb", ExpectedOutput: "19"},
+	{Input: "bvwbjplbgvbhsrlpgdmjqwftvncz", ExpectedOutput: "23"},
+	{Input: "nppdvjthqldpwncqszvftbrmjlhg", ExpectedOutput: "23"},
+	{Input: "nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", ExpectedOutput: "29"},
+	{Input: "zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", ExpectedOutput: "26"}}
 
-var day7TestsPartA = []SinglePartTest{{`$ cd /
+var day7TestsPartA = []SinglePartTest{{Input: `$ cd /
 $ ls
 dir a
 14848514 b.txt
@@ -122,9 +122,9 @@ $ ls
 4060174 j
 8033020 d.log
 5626152 d.ext
-7214296 k`, "95437"}}
+7214296 k`, ExpectedOutput: "95437"}}
 
-var day7TestsPartB = []SinglePartTest{{`$ cd /
+var day7TestsPartB = []SinglePartTest{{Input: `$ cd /
 $ ls
 dir a
 14848514 b.txt
@@ -146,46 +146,46 @@ $ ls
 4060174 j
 8033020 d.log
 5626152 d.ext
-7214296 k`, "24933642"}}
+7214296 k`, ExpectedOutput: "24933642"}}
 
-var day8TestsPartA = []SinglePartTest{{`30373
+var day8TestsPartA = []SinglePartTest{{Input: `30373
 25512
 65332
 33549
-35390`, "21"}}
+35390`, ExpectedOutput: "21"}}
 
-var day8TestsPartB = []SinglePartTest{{`30373
+var day8TestsPartB = []SinglePartTest{{Input: `30373
 25512
 65332
 33549
-35390`, "8"}}
+35390`, ExpectedOutput: "8"}}
 
-var day9TestsPartA = []SinglePartTest{{`R 4
+var day9TestsPartA = []SinglePartTest{{Input: `R 4
 U 4
 L 3
 D 1
 R 4
 D 1
 L 5
-R 2`, "13"}}
+R 2`, ExpectedOutput: "13"}}
 
-var day9TestsPartB = []SinglePartTest{{`R 4
+var day9TestsPartB = []SinglePartTest{{Input: `R 4
 U 4
 L 3
 D 1
 R 4
 D 1
 L 5
-R 2`, "1"}, {`R 5
+R 2`, ExpectedOutput: "1"}, {Input: `R 5
 U 8
 L 8
 D 3
 R 17
 D 10
 L 25
-U 20`, "36"}}
+U 20`, ExpectedOutput: "36"}}
 
-var day10TestsPartA = []SinglePartTest{{`addx 15
+var day10TestsPartA = []SinglePartTest{{Input: `addx 15
 addx -11
 addx 6
 addx -3
@@ -330,9 +330,9 @@ addx -6
 addx -11
 noop
 noop
-noop`, "13140"}}
+noop`, ExpectedOutput: "13140"}}
 
-var day10TestsPartB = []SinglePartTest{{`addx 15
+var day10TestsPartB = []SinglePartTest{{Input: `addx 15
 addx -11
 addx 6
 addx -3
@@ -477,9 +477,14 @@ addx -6
 addx -11
 noop
 noop
-noop`, "(See image!)"}}
+noop`, ExpectedOutput: `##..##..##..##..##..##..##..##..##..##..
+###...###...###...###...###...###...###.
+####....####....####....####....####....
+#####.....#####.....#####.....#####.....
+######......######......######......####
+#######.......#######.......#######.....`}}
 
-var day11TestsPartA = []SinglePartTest{{`Monkey 0:
+var day11TestsPartA = []SinglePartTest{{Input: `Monkey 0:
 Starting items: 79, 98
 Operation: new = old * 19
 Test: divisible by 23
@@ -505,9 +510,9 @@ Starting items: 74
 Operation: new = old + 3
 Test: divisible by 17
   If true: throw to monkey 0
-  If false: throw to monkey 1`, "10605"}}
+  If false: throw to monkey 1`, ExpectedOutput: "10605"}}
 
-var day11TestsPartB = []SinglePartTest{{`Monkey 0:
+var day11TestsPartB = []SinglePartTest{{Input: `Monkey 0:
 Starting items: 79, 98
 Operation: new = old * 19
 Test: divisible by 23
@@ -533,21 +538,21 @@ Starting items: 74
 Operation: new = old + 3
 Test: divisible by 17
   If true: throw to monkey 0
-  If false: throw to monkey 1`, "2713310158"}}
+  If false: throw to monkey 1`, ExpectedOutput: "2713310158"}}
 
-var day12TestsPartA = []SinglePartTest{{`Sabqponm
+var day12TestsPartA = []SinglePartTest{{Input: `Sabqponm
 abcryxxl
 accszExk
 acctuvwj
-abdefghi`, "31"}}
+abdefghi`, ExpectedOutput: "31"}}
 
-var day12TestsPartB = []SinglePartTest{{`Sabqponm
+var day12TestsPartB = []SinglePartTest{{Input: `Sabqponm
 abcryxxl
 accszExk
 acctuvwj
-abdefghi`, "29"}}
+abdefghi`, ExpectedOutput: "29"}}
 
-var day13TestsPartA = []SinglePartTest{{`[1,1,3,1,1]
+var day13TestsPartA = []SinglePartTest{{Input: `[1,1,3,1,1]
 [1,1,5,1,1]
 
 [[1],[2,3,4]]
@@ -569,9 +574,9 @@ var day13TestsPartA = []SinglePartTest{{`[1,1,3,1,1]
 [[]]
 
 [1,[2,[3,[4,[5,6,7]]]],8,9]
-[1,[2,[3,[4,[5,6,0]]]],8,9]`, "13"}}
+[1,[2,[3,[4,[5,6,0]]]],8,9]`, ExpectedOutput: "13"}}
 
-var day13TestsPartB = []SinglePartTest{{`[1,1,3,1,1]
+var day13TestsPartB = []SinglePartTest{{Input: `[1,1,3,1,1]
 [1,1,5,1,1]
 
 [[1],[2,3,4]]
@@ -593,15 +598,15 @@ var day13TestsPartB = []SinglePartTest{{`[1,1,3,1,1]
 [[]]
 
 [1,[2,[3,[4,[5,6,7]]]],8,9]
-[1,[2,[3,[4,[5,6,0]]]],8,9]`, "140"}}
+[1,[2,[3,[4,[5,6,0]]]],8,9]`, ExpectedOutput: "140"}}
 
-var day14TestsPartA = []SinglePartTest{{`498,4 -> 498,6 -> 496,6
-503,4 -> 502,4 -> 502,9 -> 494,9`, "24"}}
+var day14TestsPartA = []SinglePartTest{{Input: `498,4 -> 498,6 -> 496,6
+503,4 -> 502,4 -> 502,9 -> 494,9`, ExpectedOutput: "24"}}
 
-var day14TestsPartB = []SinglePartTest{{`498,4 -> 498,6 -> 496,6
-503,4 -> 502,4 -> 502,9 -> 494,9`, "93"}}
+var day14TestsPartB = []SinglePartTest{{Input: `498,4 -> 498,6 -> 496,6
+503,4 -> 502,4 -> 502,9 -> 494,9`, ExpectedOutput: "93"}}
 
-var day15TestsPartA = []SinglePartTest{{`Sensor at x=2, y=18: closest beacon is at x=-2, y=15
+var day15TestsPartA = []SinglePartTest{{Input: `Sensor at x=2, y=18: closest beacon is at x=-2, y=15
 Sensor at x=9, y=16: closest beacon is at x=10, y=16
 Sensor at x=13, y=2: closest beacon is at x=15, y=3
 Sensor at x=12, y=14: closest beacon is at x=10, y=16
@@ -614,9 +619,10 @@ Sensor at x=20, y=14: closest beacon is at x=25, y=17
 Sensor at x=17, y=20: closest beacon is at x=21, y=22
 Sensor at x=16, y=7: closest beacon is at x=15, y=3
 Sensor at x=14, y=3: closest beacon is at x=15, y=3
-Sensor at x=20, y=1: closest beacon is at x=15, y=3`, "26"}}
+Sensor at x=20, y=1: closest beacon is at x=15, y=3`, ExpectedOutput: "26",
+	Params: Params{&day15Row: 10}}}
 
-var day15TestsPartB = []SinglePartTest{{`Sensor at x=2, y=18: closest beacon is at x=-2, y=15
+var day15TestsPartB = []SinglePartTest{{Input: `Sensor at x=2, y=18: closest beacon is at x=-2, y=15
 Sensor at x=9, y=16: closest beacon is at x=10, y=16
 Sensor at x=13, y=2: closest beacon is at x=15, y=3
 Sensor at x=12, y=14: closest beacon is at x=10, y=16
@@ -629,9 +635,10 @@ Sensor at x=20, y=14: closest beacon is at x=25, y=17
 Sensor at x=17, y=20: closest beacon is at x=21, y=22
 Sensor at x=16, y=7: closest beacon is at x=15, y=3
 Sensor at x=14, y=3: closest beacon is at x=15, y=3
-Sensor at x=20, y=1: closest beacon is at x=15, y=3`, "56000011"}}
+Sensor at x=20, y=1: closest beacon is at x=15, y=3`, ExpectedOutput: "56000011",
+	Params: Params{&day15SearchLimit: 20}}}
 
-var day16TestsPartA = []SinglePartTest{{`Valve AA has flow rate=0; tunnels lead to valves DD, II, BB
+var day16TestsPartA = []SinglePartTest{{Input: `Valve AA has flow rate=0; tunnels lead to valves DD, II, BB
 Valve BB has flow rate=13; tunnels lead to valves CC, AA
 Valve CC has flow rate=2; tunnels lead to valves DD, BB
 Valve DD has flow rate=20; tunnels lead to valves CC, AA, EE
@@ -640,9 +647,9 @@ Valve FF has flow rate=0; tunnels lead to valves EE, GG
 Valve GG has flow rate=0; tunnels lead to valves FF, HH
 Valve HH has flow rate=22; tunnel leads to valve GG
 Valve II has flow rate=0; tunnels lead to valves AA, JJ
-Valve JJ has flow rate=21; tunnel leads to valve II`, "1651"}}
+Valve JJ has flow rate=21; tunnel leads to valve II`, ExpectedOutput: "1651"}}
 
-var day16TestsPartB = []SinglePartTest{{`Valve AA has flow rate=0; tunnels lead to valves DD, II, BB
+var day16TestsPartB = []SinglePartTest{{Input: `Valve AA has flow rate=0; tunnels lead to valves DD, II, BB
 Valve BB has flow rate=13; tunnels lead to valves CC, AA
 Valve CC has flow rate=2; tunnels lead to valves DD, BB
 Valve DD has flow rate=20; tunnels lead to valves CC, AA, EE
@@ -651,15 +658,15 @@ Valve FF has flow rate=0; tunnels lead to valves EE, GG
 Valve GG has flow rate=0; tunnels lead to valves FF, HH
 Valve HH has flow rate=22; tunnel leads to valve GG
 Valve II has flow rate=0; tunnels lead to valves AA, JJ
-Valve JJ has flow rate=21; tunnel leads to valve II`, "1651"}}
+Valve JJ has flow rate=21; tunnel leads to valve II`, ExpectedOutput: "1707"}}
 
 var day17TestsPartA = []SinglePartTest{
-	{`>>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>`, "3068"}}
+	{Input: `>>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>`, ExpectedOutput: "3068"}}
 
 var day17TestsPartB = []SinglePartTest{
-	{`>>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>`, "1514285714288"}}
+	{Input: `>>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>`, ExpectedOutput: "1514285714288"}}
 
-var day18TestsPartA = []SinglePartTest{{`2,2,2
+var day18TestsPartA = []SinglePartTest{{Input: `2,2,2
 1,2,2
 3,2,2
 2,1,2
@@ -671,9 +678,9 @@ var day18TestsPartA = []SinglePartTest{{`2,2,2
 1,2,5
 3,2,5
 2,1,5
-2,3,5`, "64"}}
+2,3,5`, ExpectedOutput: "64"}}
 
-var day18TestsPartB = []SinglePartTest{{`2,2,2
+var day18TestsPartB = []SinglePartTest{{Input: `2,2,2
 1,2,2
 3,2,2
 2,1,2
@@ -685,33 +692,33 @@ var day18TestsPartB = []SinglePartTest{{`2,2,2
 1,2,5
 3,2,5
 2,1,5
-2,3,5`, "58"}}
+2,3,5`, ExpectedOutput: "58"}}
 
-var day19TestsPartA = []SinglePartTest{{`Blueprint 1: Each ore robot costs 4 ore. Each clay robot costs 2 ore. Each obsidian robot costs 3 ore and 14 clay. Each geode robot costs 2 ore and 7 obsidian.
+var day19TestsPartA = []SinglePartTest{{Input: `Blueprint 1: Each ore robot costs 4 ore. Each clay robot costs 2 ore. Each obsidian robot costs 3 ore and 14 clay. Each geode robot costs 2 ore and 7 obsidian.
 Blueprint 2: Each ore robot costs 2 ore. Each clay robot costs 3 ore. Each obsidian robot costs 3 ore and 8 clay. Each geode robot costs 3 ore and 12 obsidian.`,
-	"33"}}
+	ExpectedOutput: "33"}}
 
-var day19TestsPartB = []SinglePartTest{{`Blueprint 1: Each ore robot costs 4 ore. Each clay robot costs 2 ore. Each obsidian robot costs 3 ore and 14 clay. Each geode robot costs 2 ore and 7 obsidian.
+var day19TestsPartB = []SinglePartTest{{Input: `Blueprint 1: Each ore robot costs 4 ore. Each clay robot costs 2 ore. Each obsidian robot costs 3 ore and 14 clay. Each geode robot costs 2 ore and 7 obsidian.
 Blueprint 2: Each ore robot costs 2 ore. Each clay robot costs 3 ore. Each obsidian robot costs 3 ore and 8 clay. Each geode robot costs 3 ore and 12 obsidian.`,
-	"3472"}}
+	ExpectedOutput: "3472"}}
 
-var day20TestsPartA = []SinglePartTest{{`1
+var day20TestsPartA = []SinglePartTest{{Input: `1
 2
 -3
 3
 -2
 0
-4`, "3"}}
+4`, ExpectedOutput: "3"}}
 
-var day20TestsPartB = []SinglePartTest{{`1
+var day20TestsPartB = []SinglePartTest{{Input: `1
 2
 -3
 3
 -2
 0
-4`, "1623178306"}}
+4`, ExpectedOutput: "1623178306"}}
 
-var day21TestsPartA = []SinglePartTest{{`root: pppw + sjmn
+var day21TestsPartA = []SinglePartTest{{Input: `root: pppw + sjmn
 dbpl: 5
 cczh: sllz + lgvd
 zczc: 2
@@ -725,9 +732,9 @@ sllz: 4
 pppw: cczh / lfqf
 lgvd: ljgn * ptdq
 drzm: hmdt - zczc
-hmdt: 32`, "152"}}
+hmdt: 32`, ExpectedOutput: "152"}}
 
-var day21TestsPartB = []SinglePartTest{{`root: pppw + sjmn
+var day21TestsPartB = []SinglePartTest{{Input: `root: pppw + sjmn
 dbpl: 5
 cczh: sllz + lgvd
 zczc: 2
@@ -741,9 +748,9 @@ sllz: 4
 pppw: cczh / lfqf
 lgvd: ljgn * ptdq
 drzm: hmdt - zczc
-hmdt: 32`, "301"}}
+hmdt: 32`, ExpectedOutput: "301"}}
 
-var day22TestsPartA = []SinglePartTest{{`        ...#
+var day22TestsPartA = []SinglePartTest{{Input: `        ...#
         .#..
         #...
         ....
@@ -756,9 +763,9 @@ var day22TestsPartA = []SinglePartTest{{`        ...#
         .#......
         ......#.
 
-10R5L5R10L4R5L5`, "6032"}}
+10R5L5R10L4R5L5`, ExpectedOutput: "6032"}}
 
-var day22TestsPartB = []SinglePartTest{{`        ...#
+var day22TestsPartB = []SinglePartTest{{Input: `        ...#
         .#..
         #...
         ....
@@ -771,13 +778,51 @@ var day22TestsPartB = []SinglePartTest{{`        ...#
         .#......
         ......#.
 
-10R5L5R10L4R5L5`, "5031"}}
-
-var day23TestsPartA = []SinglePartTest{{``, ""}}
-var day23TestsPartB = []SinglePartTest{{``, ""}}
-
-var day24TestsPartA = []SinglePartTest{{``, ""}}
-var day24TestsPartB = []SinglePartTest{{``, ""}}
-
-var day25TestsPartA = []SinglePartTest{{``, ""}}
-var day25TestsPartB = []SinglePartTest{{``, ""}}
+10R5L5R10L4R5L5`, ExpectedOutput: "5031"}}
+
+var day23TestsPartA = []SinglePartTest{
+	{Input: `....#..
+..###.#
+#...#.#
+.#...##
+#.###..
+##.#.##
+.#..#..`, ExpectedOutput: "110"},
+	{Input: `.....
+..##.
+..#..
+.....
+..##.
+.....`, ExpectedOutput: "25"}}
+
+var day23TestsPartB = []SinglePartTest{
+	{Input: `....#..
+..###.#
+#...#.#
+.#...##
+#.###..
+##.#.##
+.#..#..`, ExpectedOutput: "20"},
+	{Input: `.....
+..##.
+..#..
+.....
+..##.
+.....`, ExpectedOutput: "4"}}
+
+var day24TestsPartA = []SinglePartTest{{Input: `#.######
+#>>.<^<#
+#.<..<<#
+#>v.><>#
+#<^v^^>#
+######.#`, ExpectedOutput: "18"}}
+
+var day24TestsPartB = []SinglePartTest{{Input: `#.######
+#>>.<^<#
+#.<..<<#
+#>v.><>#
+#<^v^^>#
+######.#`, ExpectedOutput: "54"}}
+
+var day25TestsPartA = []SinglePartTest{{Input: ``, ExpectedOutput: ""}}
+var day25TestsPartB = []SinglePartTest{{Input: ``, ExpectedOutput: ""}}
@@ -0,0 +1,75 @@
+package days
+
+import "gonum.org/v1/plot"
+
+// Visual describes how a solution can be displayed without depending on any
+// particular GUI toolkit. Solvers return a Visualizer for one alongside their
+// answer (or nil), and it's up to the caller to decide if and how to draw it.
+type Visual interface {
+	isVisual()
+}
+
+// Visualizer builds a solution's Visual when it's wanted. Nothing is drawn
+// while solving, so the command line and tests, which only want the answer,
+// don't pay for it.
+type Visualizer func() (Visual, error)
+
+// Calls v, a nil Visualizer builds a nil Visual
+func (v Visualizer) Build() (Visual, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v()
+}
+
+// Visualizer for a Visual that's already been built, ex: because the answer
+// is read from it, or that's cheap to build like a PlaybackVisual that only
+// draws its frames when asked
+func visualOf(v Visual) Visualizer {
+	return func() (Visual, error) { return v, nil }
+}
+
+// A gonum plot, Name is a file name suitable for the rendered image
+type PlotVisual struct {
+	Plot *plot.Plot
+	Name string
+}
+
+// Text to show as-is. Monospace is set for ascii art such as maps and grids
+type TextVisual struct {
+	Text      string
+	Monospace bool
+}
+
+// Tree of labels, Children[""] holds the root label(s)
+type TreeVisual struct {
+	Children map[string][]string
+}
+
+// Grid of cells where Highlight[y][x] marks the cells to draw differently
+type GridVisual struct {
+	Cells     [][]string
+	Highlight [][]bool
+}
+
+// Text with the characters in [Start, End) highlighted
+type HighlightVisual struct {
+	Text       string
+	Start, End int
+}
+
+// Simulation shown a step at a time. Frame(i) draws the state after i steps,
+// for i in [0, Frames), so a long simulation doesn't need every state drawn up
+// front. Label(i) describes the step and may be nil.
+type PlaybackVisual struct {
+	Frames int
+	Frame  func(i int) Visual
+	Label  func(i int) string
+}
+
+func (PlotVisual) isVisual()      {}
+func (TextVisual) isVisual()      {}
+func (TreeVisual) isVisual()      {}
+func (GridVisual) isVisual()      {}
+func (HighlightVisual) isVisual() {}
+func (PlaybackVisual) isVisual()  {}
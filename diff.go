@@ -0,0 +1,155 @@
+package main
+
+import (
+	"strings"
+
+	"example.com/advent2022/days"
+)
+
+type diffOp int
+
+const (
+	diffSame diffOp = iota
+	// Only in the expected text
+	diffMissing
+	// Only in the actual text
+	diffExtra
+)
+
+type diffSpan struct {
+	op   diffOp
+	text string
+}
+
+// Past this many comparisons the texts are just shown as entirely different,
+// answers are short enough that it only matters for broken solvers
+const maxDiffCells = 1 << 20
+
+// Character level diff turning expected into actual, using the longest common
+// subsequence so a single missing character doesn't mark the rest of the line
+func diffChars(expected, actual string) []diffSpan {
+	a, b := []rune(expected), []rune(actual)
+	if len(a)*len(b) > maxDiffCells {
+		return appendSpan(appendSpan(nil, diffMissing, expected), diffExtra, actual)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var spans []diffSpan
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			spans = appendSpan(spans, diffSame, string(a[i]))
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			spans = appendSpan(spans, diffMissing, string(a[i]))
+			i++
+		default:
+			spans = appendSpan(spans, diffExtra, string(b[j]))
+			j++
+		}
+	}
+	spans = appendSpan(spans, diffMissing, string(a[i:]))
+	return appendSpan(spans, diffExtra, string(b[j:]))
+}
+
+// Adds text to the last span if it's the same op, skips empty text
+func appendSpan(spans []diffSpan, op diffOp, text string) []diffSpan {
+	if text == "" {
+		return spans
+	}
+	if n := len(spans); n > 0 && spans[n-1].op == op {
+		spans[n-1].text += text
+		return spans
+	}
+	return append(spans, diffSpan{op: op, text: text})
+}
+
+// Lines up expected and actual character by character, for answers drawn as
+// pictures (day 10's CRT) where what matters is which cells differ. Both grids
+// are padded to the same size, with every cell that doesn't match highlighted.
+func diffGrid(expected, actual string) (days.GridVisual, days.GridVisual) {
+	expLines, actLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	height := len(expLines)
+	if len(actLines) > height {
+		height = len(actLines)
+	}
+	width := 0
+	for _, l := range append(expLines, actLines...) {
+		if n := len([]rune(l)); n > width {
+			width = n
+		}
+	}
+
+	cells := func(lines []string) [][]string {
+		grid := make([][]string, height)
+		for y := range grid {
+			grid[y] = make([]string, width)
+			var line []rune
+			if y < len(lines) {
+				line = []rune(lines[y])
+			}
+			for x := range grid[y] {
+				grid[y][x] = " "
+				if x < len(line) {
+					grid[y][x] = string(line[x])
+				}
+			}
+		}
+		return grid
+	}
+	exp := days.GridVisual{Cells: cells(expLines), Highlight: make([][]bool, height)}
+	act := days.GridVisual{Cells: cells(actLines), Highlight: make([][]bool, height)}
+	for y := 0; y < height; y++ {
+		exp.Highlight[y] = make([]bool, width)
+		act.Highlight[y] = make([]bool, width)
+		for x := 0; x < width; x++ {
+			differs := exp.Cells[y][x] != act.Cells[y][x]
+			exp.Highlight[y][x] = differs
+			act.Highlight[y][x] = differs
+		}
+	}
+	return exp, act
+}
+
+// Expected over actual with the characters from diffChars lined up in columns.
+// Characters only one side has are highlighted, with a gap left on the other.
+func diffCharGrid(expected, actual string) days.GridVisual {
+	grid := days.GridVisual{Cells: make([][]string, 2), Highlight: make([][]bool, 2)}
+	add := func(exp, act string, highlight bool) {
+		grid.Cells[0] = append(grid.Cells[0], exp)
+		grid.Cells[1] = append(grid.Cells[1], act)
+		grid.Highlight[0] = append(grid.Highlight[0], highlight)
+		grid.Highlight[1] = append(grid.Highlight[1], highlight)
+	}
+	for _, span := range diffChars(expected, actual) {
+		for _, r := range span.text {
+			switch span.op {
+			case diffSame:
+				add(string(r), string(r), false)
+			case diffMissing:
+				add(string(r), " ", true)
+			case diffExtra:
+				add(" ", string(r), true)
+			}
+		}
+	}
+	return grid
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"example.com/advent2022/days"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Size plots are drawn at, both in the window and when saved
+const (
+	plotWidth  = 5 * vg.Inch
+	plotHeight = 3 * vg.Inch
+)
+
+// Text visualizations are saved as pictures of the text in this font
+var exportFont = font.Font{Typeface: "Liberation", Variant: "Mono", Size: 12}
+
+// Space around text visualizations when saved
+const exportMargin = 10 * vg.Millimeter
+
+// Formats a visualization can be saved in, picked by the file's extension
+var exportFormats = []string{"png", "svg", "pdf"}
+
+// Format to save path in, from its extension
+func exportFormat(path string) (string, error) {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	for _, f := range exportFormats {
+		if f == format {
+			return format, nil
+		}
+	}
+	return "", errors.New("can't save a visualization as " + path + ", expected a ." + strings.Join(exportFormats, ", .") + " file")
+}
+
+// Name suggested when saving the visualization
+func visualFileName(v days.Visual) string {
+	if playback, ok := v.(days.PlaybackVisual); ok {
+		v = lastFrame(playback)
+	}
+	if plot, ok := v.(days.PlotVisual); ok && plot.Name != "" {
+		return plot.Name
+	}
+	return "visualization.png"
+}
+
+// Saves the visualization to path in the format given by its extension
+func saveVisual(path string, v days.Visual) error {
+	format, err := exportFormat(path)
+	if err != nil {
+		return err
+	}
+	// Rendered first so a visualization that can't be saved doesn't leave an empty file
+	var b bytes.Buffer
+	if err := writeVisual(&b, v, format); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
+
+// Draws the visualization in format (one of exportFormats). Plots are drawn
+// as they are in the window, everything else is drawn as text. Simulations are
+// saved as their final state.
+func writeVisual(w io.Writer, v days.Visual, format string) error {
+	var c io.WriterTo
+	switch vis := v.(type) {
+	case nil:
+		return errors.New("there's no visualization to save")
+	case days.PlaybackVisual:
+		return writeVisual(w, lastFrame(vis), format)
+	case days.PlotVisual:
+		var err error
+		if c, err = vis.Plot.WriterTo(plotWidth, plotHeight, format); err != nil {
+			return err
+		}
+	default:
+		lines, ok := visualText(v)
+		if !ok {
+			return fmt.Errorf("don't know how to save a %T", v)
+		}
+		var err error
+		if c, err = drawText(lines, format); err != nil {
+			return err
+		}
+	}
+	_, err := c.WriteTo(w)
+	return err
+}
+
+func lastFrame(vis days.PlaybackVisual) days.Visual {
+	if vis.Frames == 0 {
+		return nil
+	}
+	return vis.Frame(vis.Frames - 1)
+}
+
+// Piece of a line of text, highlighted pieces are drawn in red as in the window
+type textRun struct {
+	text      string
+	highlight bool
+}
+
+// Lines of text showing the visualization, ok is false for visualizations
+// that aren't text (plots)
+func visualText(v days.Visual) (lines [][]textRun, ok bool) {
+	switch vis := v.(type) {
+	case days.TextVisual:
+		return plainLines(vis.Text), true
+	case days.HighlightVisual:
+		return splitLines([]textRun{
+			{text: vis.Text[:vis.Start]},
+			{text: vis.Text[vis.Start:vis.End], highlight: true},
+			{text: vis.Text[vis.End:]},
+		}), true
+	case days.GridVisual:
+		lines := make([][]textRun, len(vis.Cells))
+		for y, row := range vis.Cells {
+			for x, cell := range row {
+				lines[y] = append(lines[y], textRun{text: cell, highlight: vis.Highlight[y][x]})
+			}
+		}
+		return lines, true
+	case days.TreeVisual:
+		var sb strings.Builder
+		var walk func(label string, depth int)
+		walk = func(label string, depth int) {
+			sb.WriteString(strings.Repeat("  ", depth) + label + "\n")
+			for _, child := range vis.Children[label] {
+				walk(child, depth+1)
+			}
+		}
+		for _, root := range vis.Children[""] {
+			walk(root, 0)
+		}
+		return plainLines(strings.TrimSuffix(sb.String(), "\n")), true
+	default:
+		return nil, false
+	}
+}
+
+func plainLines(text string) [][]textRun {
+	return splitLines([]textRun{{text: text}})
+}
+
+// Breaks runs up at newlines
+func splitLines(runs []textRun) [][]textRun {
+	lines := [][]textRun{nil}
+	for _, run := range runs {
+		for i, part := range strings.Split(run.text, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+			}
+			if part != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], textRun{text: part, highlight: run.highlight})
+			}
+		}
+	}
+	return lines
+}
+
+// Draws the lines in a monospace font on a white background
+func drawText(lines [][]textRun, format string) (vg.CanvasWriterTo, error) {
+	face := font.DefaultCache.Lookup(exportFont, exportFont.Size)
+	charWidth := face.Width("0")
+	extents := face.Extents()
+
+	columns := 0
+	for _, line := range lines {
+		n := 0
+		for _, run := range line {
+			n += len([]rune(run.text))
+		}
+		if n > columns {
+			columns = n
+		}
+	}
+	width := 2*exportMargin + vg.Length(columns)*charWidth
+	height := 2*exportMargin + vg.Length(len(lines))*extents.Height
+
+	c, err := draw.NewFormattedCanvas(width, height, format)
+	if err != nil {
+		return nil, err
+	}
+	c.SetColor(color.White)
+	var background vg.Path
+	background.Move(vg.Point{})
+	background.Line(vg.Point{X: width})
+	background.Line(vg.Point{X: width, Y: height})
+	background.Line(vg.Point{Y: height})
+	background.Close()
+	c.Fill(background)
+
+	// vg measures from the bottom left, lines are drawn from the top down
+	for i, line := range lines {
+		pt := vg.Point{X: exportMargin, Y: height - exportMargin - extents.Ascent - vg.Length(i)*extents.Height}
+		for _, run := range line {
+			if run.highlight {
+				c.SetColor(color.RGBA{255, 0, 0, 255})
+			} else {
+				c.SetColor(color.Black)
+			}
+			c.FillString(face, pt, run.text)
+			pt.X += vg.Length(len([]rune(run.text))) * charWidth
+		}
+	}
+	return c, nil
+}
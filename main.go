@@ -1,166 +1,548 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"time"
 
 	"example.com/advent2022/days"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(runCLI(os.Args[2:]))
+	}
+	inputDir := flag.String("inputs", days.DefaultInputDir(), "directory holding the puzzle inputs")
+	answersDir := flag.String("answers", days.DefaultAnswersDir(), "directory holding the recorded answers")
+	flag.Parse()
+
+	answers, err := loadAnswers(*answersDir)
+	if err != nil {
+		// Solving still works, there's just nothing to verify against
+		fmt.Fprintln(os.Stderr, "Failed to read answers, err:", err)
+		answers = days.Answers{}
+	}
+
 	a := app.New()
-	w := a.NewWindow("Friendly's Advent of code 2022")
+	w := a.NewWindow("Friendly's Advent of code")
 	w.SetMaster()
+	s := &session{inputDir: *inputDir, answers: answers, statuses: newStatusBoard(), window: w}
 
 	title := widget.NewLabel("Day Name")
+	link := widget.NewHyperlink("Puzzle description", nil)
+	link.Hide()
 	content := container.NewMax()
 
+	// Views are kept around so that a solve running in the background can be
+	// left to finish while looking at other days
+	dayViews := make(map[days.Key]*dayView)
 	setDay := func(d days.Day) {
-		title.SetText("Day " + strconv.Itoa(d.Number))
-		vbox := container.NewVBox()
-		vbox.Add(widget.NewLabel("Part A:"))
-		vbox.Add(widget.NewLabel(d.PartAPrompt))
-		solveButtonA := widget.Button{Text: "Solve part A"}
-		solveButtonA.OnTapped = func() {
-			solveButtonA.Disable()
-
-			// TODO: use progress bar
-			vbox.Add(widget.NewLabel("Testing part A..."))
-			allTestsPassed := true
-			for i, test := range d.PartATests {
-				actualOutput, testImg, err := d.Solver.SolvePartA(test.Input)
-				if err != nil {
-					fail_str := fmt.Sprint("Day ", d.Number, " part A: test ", i, " returned err: ", err.Error())
-					fmt.Println(fail_str)
-					vbox.Add(widget.NewLabel(fail_str))
-					allTestsPassed = false
-				} else if actualOutput != test.ExpectedOutput {
-					fail_str := fmt.Sprint("Day ", d.Number, " part A: test ", i, " failed. Got: ", actualOutput, ", expected: ", test.ExpectedOutput)
-					fmt.Println(fail_str)
-					vbox.Add(widget.NewLabel(fail_str))
-					if testImg != nil {
-						vbox.Add(widget.NewLabel("Part A failed test image:"))
-						vbox.Add(testImg)
-					}
-					allTestsPassed = false
-				}
-
-				if i == 0 {
-					vbox.Add(widget.NewLabel("Part A test 0 image:"))
-					vbox.Add(testImg)
-				}
+		title.SetText(fmt.Sprintf("Day %d: %s (%d)", d.Number, d.Title, d.Year))
+		if err := link.SetURLFromString(d.URL()); err == nil {
+			link.Show()
+		}
+		view, ok := dayViews[d.Key()]
+		if !ok {
+			view = newDayView(d, s)
+			dayViews[d.Key()] = view
+		}
+		// Input may have been added or removed since the view was made
+		view.checkInput()
+
+		content.Objects = []fyne.CanvasObject{view.box}
+		content.Refresh()
+	}
+
+	day := container.NewBorder(
+		container.NewVBox(container.NewHBox(title, link), widget.NewSeparator()), nil, nil, nil, content)
+	w.SetContent(container.NewHSplit(makeNav(setDay, s.statuses), day))
+	w.Resize(fyne.NewSize(1500, 1000))
+	w.ShowAndRun()
+}
+
+// State shared by every day's view
+type session struct {
+	inputDir string
+	answers  days.Answers
+	statuses *statusBoard
+	window   fyne.Window
+}
+
+type dayView struct {
+	day         days.Day
+	session     *session
+	inputStatus *widget.Label
+	customInput *widget.Entry
+	useCustom   *widget.Check
+	box         fyne.CanvasObject
+}
+
+func newDayView(d days.Day, s *session) *dayView {
+	v := &dayView{day: d, session: s, inputStatus: widget.NewLabel("")}
+	v.inputStatus.Hide()
+	vbox := container.NewVBox(v.inputStatus, v.makeCustomInput(s.window))
+	v.addPartView(vbox, days.PartA)
+	v.addPartView(vbox, days.PartB)
+	v.box = container.NewVScroll(vbox)
+	return v
+}
+
+// Pane for pasting or opening an input to solve instead of the puzzle input
+func (v *dayView) makeCustomInput(w fyne.Window) fyne.CanvasObject {
+	v.customInput = widget.NewMultiLineEntry()
+	v.customInput.SetPlaceHolder("Paste an input here or open a file")
+	v.customInput.SetMinRowsVisible(8)
+	v.customInput.TextStyle.Monospace = true
+	v.useCustom = widget.NewCheck("Solve this instead of the puzzle input", nil)
+
+	openButton := widget.NewButton("Open file…", func() {
+		dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
 			}
-			if !allTestsPassed {
+			if r == nil {
+				// Dialog was cancelled
 				return
 			}
-
-			vbox.Add(widget.NewLabel("All tests passed"))
-			vbox.Add(widget.NewLabel("Solving part A..."))
-			solution, solutionImg, err := d.Solver.SolvePartA(d.PuzzleInput)
+			defer r.Close()
+			b, err := io.ReadAll(r)
 			if err != nil {
-				fail_str := fmt.Sprint("Day ", d.Number, " solution returned err: ", err.Error())
-				fmt.Println(fail_str)
-				vbox.Add(widget.NewLabel(fail_str))
+				dialog.ShowError(err, w)
 				return
 			}
-			result := widget.NewEntry()
-			result.SetText(solution)
-			result.Disable()
-			vbox.Add(widget.NewForm(&widget.FormItem{Text: "Puzzle solution is: ", Widget: result}))
-			fmt.Println("Part A solution is: ", solution)
+			v.customInput.SetText(days.NormalizeInput(string(b)))
+			v.useCustom.SetChecked(true)
+		}, w)
+	})
+
+	pane := container.NewBorder(nil, container.NewHBox(openButton, v.useCustom), nil, nil, v.customInput)
+	return widget.NewAccordion(widget.NewAccordionItem("Custom input", pane))
+}
 
-			vbox.Add(solutionImg)
+// Input to solve once the tests pass, either the custom input or the day's
+// puzzle input. puzzle is set for the puzzle input, only its solutions can be
+// checked against the recorded answers.
+func (v *dayView) loadInput() (input string, puzzle bool, err error) {
+	if v.useCustom.Checked {
+		if v.customInput.Text == "" {
+			return "", false, errors.New("custom input is empty")
 		}
+		return days.NormalizeInput(v.customInput.Text), false, nil
+	}
+	input, err = v.day.LoadPuzzleInput(v.session.inputDir)
+	return input, true, err
+}
 
-		vbox.Add(&solveButtonA)
-
-		vbox.Add(widget.NewLabel("Part B:"))
-		vbox.Add(widget.NewLabel(d.PartBPrompt))
-
-		solveButtonB := widget.Button{Text: "Solve part B"}
-		solveButtonB.OnTapped = func() {
-			solveButtonB.Disable()
-
-			// TODO: use progress bar
-			vbox.Add(widget.NewLabel("Testing part B..."))
-			allTestsPassed := true
-			for i, test := range d.PartBTests {
-				actualOutput, testImg, err := d.Solver.SolvePartB(test.Input)
-				if err != nil {
-					fail_str := fmt.Sprint("Day ", d.Number, " part B: test ", i, " returned err: ", err.Error())
-					fmt.Println(fail_str)
-					vbox.Add(widget.NewLabel(fail_str))
-					allTestsPassed = false
-				} else if actualOutput != test.ExpectedOutput {
-					fail_str := fmt.Sprint("Day ", d.Number, " part B: test ", i, " failed. Got: ", actualOutput, ", expected: ", test.ExpectedOutput)
-					fmt.Println(fail_str)
-					vbox.Add(widget.NewLabel(fail_str))
-					if testImg != nil {
-						vbox.Add(widget.NewLabel("Part B failed test image:"))
-						vbox.Add(testImg)
-					}
-					allTestsPassed = false
-				}
-
-				if i == 0 {
-					vbox.Add(widget.NewLabel("Part B test 0 image:"))
-					vbox.Add(testImg)
-				}
-			}
-			if !allTestsPassed {
+// Shows a warning if the day's puzzle input can't be read. Tests can still be
+// run without it.
+func (v *dayView) checkInput() {
+	_, err := v.day.LoadPuzzleInput(v.session.inputDir)
+	if err == nil {
+		v.inputStatus.Hide()
+		return
+	}
+	v.inputStatus.SetText(inputErrorMessage(err))
+	v.inputStatus.Show()
+}
+
+func inputErrorMessage(err error) string {
+	if errors.Is(err, days.ErrInputMissing) {
+		return "Input missing: " + err.Error() + ". The tests can still be run, or use a custom input."
+	}
+	return "Failed to read input, err: " + err.Error()
+}
+
+// What a run of a part covers
+type runMode int
+
+const (
+	runTestsAndInput runMode = iota
+	runTestsOnly
+	runInputOnly
+)
+
+func (m runMode) String() string {
+	switch m {
+	case runTestsOnly:
+		return "tests"
+	case runInputOnly:
+		return "input"
+	default:
+		return "tests and input"
+	}
+}
+
+func (v *dayView) addPartView(vbox *fyne.Container, p days.Part) {
+	d := v.day
+	vbox.Add(widget.NewLabel("Part " + p.String() + ":"))
+	vbox.Add(widget.NewLabel(d.Prompt(p)))
+
+	solveButton := widget.NewButton("Solve part "+p.String(), nil)
+	if !d.Implemented(p) {
+		solveButton.Disable()
+		vbox.Add(container.NewHBox(solveButton, widget.NewLabel("Not implemented yet")))
+		return
+	}
+	testsButton := widget.NewButton("Run tests", nil)
+	inputButton := widget.NewButton("Solve input only", nil)
+	runButtons := []*widget.Button{solveButton, testsButton, inputButton}
+	cancelButton := widget.NewButton("Cancel", nil)
+	cancelButton.Disable()
+	clearButton := widget.NewButton("Clear", nil)
+	var params *paramForm
+	if len(d.Params(p)) > 0 {
+		params = newParamForm(d.Params(p))
+		vbox.Add(params.widget())
+	}
+	progress := newProgressView()
+	// Each run gets its own section so earlier ones can be compared. Only one
+	// run of a part happens at a time, it's the only thing writing to its section.
+	runs := widget.NewAccordion()
+	runs.MultiOpen = true
+	runCount := 0
+
+	run := func(mode runMode) {
+		var values days.Params
+		var changed bool
+		if params != nil {
+			var err error
+			if values, changed, err = params.values(); err != nil {
+				dialog.ShowError(err, v.session.window)
 				return
 			}
+		}
+		for _, b := range runButtons {
+			b.Disable()
+		}
+		clearButton.Disable()
 
-			vbox.Add(widget.NewLabel("All tests passed"))
-			vbox.Add(widget.NewLabel("Solving part B..."))
-			solution, sol_img, err := d.Solver.SolvePartB(d.PuzzleInput)
-			if err != nil {
-				fail_str := fmt.Sprint("Day ", d.Number, " Part B solution returned err: ", err.Error())
-				fmt.Println(fail_str)
-				vbox.Add(widget.NewLabel(fail_str))
-				return
+		runCount++
+		title := fmt.Sprintf("Run %d: %s, started %s", runCount, mode, time.Now().Format("15:04:05"))
+		output := container.NewVBox()
+		item := widget.NewAccordionItem(title+" (running)", output)
+		runs.Append(item)
+		runs.Open(len(runs.Items) - 1)
+
+		// Solve in the background so the window stays responsive
+		ctx, cancel := context.WithCancel(context.Background())
+		ctx = days.WithProgress(ctx, throttleProgress(progress.update, 100*time.Millisecond))
+		cancelButton.OnTapped = func() {
+			cancelButton.Disable()
+			cancel()
+		}
+		cancelButton.Enable()
+		progress.start()
+		go func() {
+			defer cancel()
+			status, ok := v.solvePartInto(ctx, output, p, mode, values, changed)
+			if ok {
+				v.session.statuses.set(d, p, status)
+			}
+			item.Title = title + " (" + runOutcome(ctx, mode, status, ok) + ")"
+			runs.Refresh()
+			progress.finish()
+			cancelButton.Disable()
+			for _, b := range runButtons {
+				b.Enable()
+			}
+			clearButton.Enable()
+		}()
+	}
+	solveButton.OnTapped = func() { run(runTestsAndInput) }
+	testsButton.OnTapped = func() { run(runTestsOnly) }
+	inputButton.OnTapped = func() { run(runInputOnly) }
+	clearButton.OnTapped = func() {
+		runs.Items = nil
+		runs.Refresh()
+		v.session.statuses.set(d, p, statusNotRun)
+	}
+
+	vbox.Add(container.NewHBox(solveButton, testsButton, inputButton, cancelButton, clearButton))
+	vbox.Add(progress.box)
+	vbox.Add(runs)
+}
+
+// Short description of how a run went, for its section's title
+func runOutcome(ctx context.Context, mode runMode, status partStatus, ok bool) string {
+	switch {
+	case ctx.Err() != nil:
+		return "cancelled"
+	case ok && status == statusFailing:
+		return "failed"
+	case ok && status == statusSolved:
+		return "solved"
+	case ok && status == statusVerified:
+		return "solved, matches the recorded answer"
+	case mode == runTestsOnly:
+		return "tests passed"
+	default:
+		return "finished"
+	}
+}
+
+// Runs the tests and then the puzzle input for the part (or just one of them,
+// depending on mode), adding the results to vbox as they come in. Meant to be
+// called off the UI goroutine; everything is built and rendered here so the
+// only thing touching the window is vbox.Add. The input is solved with params,
+// customParams is set when they aren't all the defaults. Returns the
+// part's new status, ok is false if the run didn't get far enough to tell
+// (cancelled, only the tests were run and passed, or there was no input to solve).
+func (v *dayView) solvePartInto(ctx context.Context, vbox *fyne.Container, p days.Part, mode runMode, params days.Params, customParams bool) (status partStatus, ok bool) {
+	d := v.day
+	if mode != runInputOnly {
+		if !v.testPartInto(ctx, vbox, p) {
+			if ctx.Err() != nil {
+				return 0, false
 			}
-			result := widget.NewEntry()
-			result.SetText(solution)
-			result.Disable()
-			vbox.Add(widget.NewForm(&widget.FormItem{Text: "Puzzle solution is: ", Widget: result}))
-			fmt.Println("Part B solution is: ", solution)
+			return statusFailing, true
+		}
+		if mode == runTestsOnly {
+			return 0, false
+		}
+	}
 
-			vbox.Add(sol_img)
+	input, puzzle, err := v.loadInput()
+	if err != nil {
+		vbox.Add(widget.NewLabel(inputErrorMessage(err)))
+		return 0, false
+	}
+	vbox.Add(widget.NewLabel("Solving part " + p.String() + "..."))
+	res, stats, err := measureSolve(ctx, d, p, input, params)
+	if err != nil {
+		fail_str := fmt.Sprint("Day ", d.Number, " part ", p, " solution returned err: ", err.Error())
+		addSolveError(vbox, fail_str, fmt.Sprint("Day ", d.Number, " part ", p, " input"), input, err)
+		if ctx.Err() != nil {
+			return 0, false
 		}
+		return statusFailing, true
+	}
+	if !puzzle || customParams {
+		// Solving a custom input (or with other params) says nothing about the puzzle
+		addResult(vbox, d, p, res, stats, "", v.session.window)
+		return 0, false
+	}
+	recorded, known := v.session.answers.Get(d, p)
+	switch {
+	case !known:
+		addResult(vbox, d, p, res, stats, "None yet", v.session.window)
+		return statusSolved, true
+	case recorded == res.Answer:
+		addResult(vbox, d, p, res, stats, "Matches", v.session.window)
+		return statusVerified, true
+	default:
+		addResult(vbox, d, p, res, stats, "Doesn't match, recorded answer is: "+recorded, v.session.window)
+		return statusFailing, true
+	}
+}
 
-		vbox.Add(&solveButtonB)
+// Runs the part's tests, filling in a table of their results. Once they're
+// done the first failure (or the first test) is selected to show its details.
+// Returns true if they all passed.
+func (v *dayView) testPartInto(ctx context.Context, vbox *fyne.Container, p days.Part) bool {
+	d := v.day
+	table := newTestTable(d, p, v.session.window)
+	vbox.Add(table.box)
+	results := make([]testResult, len(d.Tests(p)))
+	for i := range results {
+		table.start(i)
+		r := runTest(ctx, d, p, i)
+		results[i] = r
+		table.finish(r)
+	}
+	table.selectFirstFailure()
+	if !allPassed(results) {
+		return false
+	}
+	vbox.Add(widget.NewLabel("All tests passed"))
+	return true
+}
 
-		content.Objects = []fyne.CanvasObject{container.NewVScroll(vbox)}
-		content.Refresh()
+// Shows the answer along with everything else in the result. check says how
+// the answer compares to the recorded one, empty if it can't be compared. w is
+// the window any save dialogs are shown in.
+func addResult(vbox *fyne.Container, d days.Day, p days.Part, res days.Result, stats allocStats, check string, w fyne.Window) {
+	var answer fyne.CanvasObject
+	if res.Kind == days.ImageOnlyAnswer {
+		// Can't be copied anyway, so point at the picture
+		answer = widget.NewLabel("Read it from the image below")
+	} else {
+		entry := widget.NewEntry()
+		entry.SetText(res.Answer)
+		entry.Disable()
+		answer = entry
+	}
+	form := widget.NewForm(&widget.FormItem{Text: "Puzzle solution is: ", Widget: answer})
+	if check != "" {
+		form.Append("Recorded answer: ", widget.NewLabel(check))
 	}
+	for _, f := range res.Facts {
+		form.Append(f.Name+": ", widget.NewLabel(f.Value))
+	}
+	form.Append("Took: ", widget.NewLabel(formatTimings(res)+", "+stats.String()))
+	vbox.Add(form)
 
-	day := container.NewBorder(
-		container.NewVBox(title, widget.NewSeparator()), nil, nil, nil, content)
-	w.SetContent(container.NewHSplit(makeNav(setDay), day))
-	w.Resize(fyne.NewSize(1500, 1000))
-	w.ShowAndRun()
+	addVisual(vbox, d, p, res.Visual, w)
+	for _, a := range res.Artifacts {
+		vbox.Add(widget.NewLabel(a.Name + ":"))
+		addVisual(vbox, d, p, a.Visual, w)
+	}
+}
+
+// Shows why solving failed. Parse errors point at the input (what says which
+// one) and panics come with their stack trace, anything else is just msg.
+func addSolveError(vbox *fyne.Container, msg, what, input string, err error) {
+	var parseErr *days.ParseError
+	var panicErr *days.PanicError
+	switch {
+	case errors.As(err, &parseErr):
+		addParseError(vbox, what, input, parseErr)
+	case errors.As(err, &panicErr):
+		addPanicError(vbox, msg, panicErr)
+	default:
+		vbox.Add(widget.NewLabel(msg))
+	}
+}
+
+// Shows msg with the panic's stack trace under it
+func addPanicError(vbox *fyne.Container, msg string, err *days.PanicError) {
+	vbox.Add(widget.NewLabel(msg))
+	stack := widget.NewLabel(string(err.Stack))
+	stack.TextStyle.Monospace = true
+	vbox.Add(widget.NewAccordion(widget.NewAccordionItem("Stack trace", container.NewHScroll(stack))))
+}
+
+// Shows why a visualization couldn't be built or drawn, with the stack trace
+// if it panicked
+func addVisualError(vbox *fyne.Container, msg string, err error) {
+	var panicErr *days.PanicError
+	if errors.As(err, &panicErr) {
+		addPanicError(vbox, msg, panicErr)
+		return
+	}
+	vbox.Add(widget.NewLabel(msg))
 }
 
-func makeNav(setDay func(days.Day)) fyne.CanvasObject {
+// Shows where in the input parsing failed rather than the whole error message
+func addParseError(vbox *fyne.Container, what, input string, err *days.ParseError) {
+	where := fmt.Sprint("line ", err.Line)
+	if err.Column > 0 {
+		where += fmt.Sprint(", column ", err.Column)
+	}
+	msg := err.Msg
+	if err.Err != nil {
+		msg += ": " + err.Err.Error()
+	}
+	vbox.Add(widget.NewLabel(what + " couldn't be parsed at " + where + ": " + msg))
+	vbox.Add(renderInputLine(input, err.Line, err.Column))
+}
+
+// Builds and draws the part's visualization along with a button to save it to a
+// file, w is the window the save dialog is shown in. The day's drawing code
+// runs through its Guard, so a panic is shown like one from solving.
+func addVisual(vbox *fyne.Container, d days.Day, p days.Part, vis days.Visualizer, w fyne.Window) {
+	guard := func(f func()) error { return d.Guard(p, f) }
+	v, err := d.BuildVisual(p, vis)
+	if err != nil {
+		fail_str := fmt.Sprint("Failed to build visualization, err: ", err.Error())
+		addVisualError(vbox, fail_str, err)
+		return
+	}
+	var obj fyne.CanvasObject
+	if panicErr := guard(func() { obj, err = renderVisual(v, guard) }); panicErr != nil {
+		err = panicErr
+	}
+	if err != nil {
+		fail_str := fmt.Sprint("Failed to draw visualization, err: ", err.Error())
+		addVisualError(vbox, fail_str, err)
+		return
+	}
+	if obj == nil {
+		return
+	}
+	vbox.Add(obj)
+	vbox.Add(container.NewHBox(widget.NewButtonWithIcon("Save visualization…", theme.DocumentSaveIcon(), func() {
+		showSaveVisual(v, guard, w)
+	})))
+}
+
+// Asks where to save the visualization, the format comes from the file name.
+// Writing it goes through guard as it can run the day's drawing code.
+func showSaveVisual(v days.Visual, guard func(func()) error, w fyne.Window) {
+	save := dialog.NewFileSave(func(wc fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if wc == nil {
+			// Dialog was cancelled
+			return
+		}
+		defer wc.Close()
+		format, err := exportFormat(wc.URI().Name())
+		if err == nil {
+			if panicErr := guard(func() { err = writeVisual(wc, v, format) }); panicErr != nil {
+				err = panicErr
+			}
+		}
+		if err != nil {
+			dialog.ShowError(err, w)
+		}
+	}, w)
+	save.SetFileName(visualFileName(v))
+	save.Show()
+}
+
+// List of an event's days, each with an icon for how far along it is: not
+// implemented, not run yet, failing, solved, or solved and matching the
+// recorded answers. Starts on the latest event, the others can be picked above
+// the list.
+func makeNav(setDay func(days.Day), statuses *statusBoard) fyne.CanvasObject {
+	years := days.Years()
+	var shown []days.Day
 	list := &widget.List{
 		Length: func() int {
-			return len(days.Days)
+			return len(shown)
 		},
 		CreateItem: func() fyne.CanvasObject {
-			return widget.NewLabel("Day TEMPLATE")
+			return container.NewHBox(widget.NewIcon(theme.RadioButtonIcon()), widget.NewLabel("Day TEMPLATE"))
 		},
 		UpdateItem: func(id widget.ListItemID, item fyne.CanvasObject) {
-			item.(*widget.Label).SetText("Day " + strconv.Itoa(days.Days[id].Number))
+			d := shown[id]
+			objects := item.(*fyne.Container).Objects
+			objects[0].(*widget.Icon).SetResource(statuses.day(d).icon())
+			objects[1].(*widget.Label).SetText("Day " + strconv.Itoa(d.Number) + ": " + d.Title)
 		},
 		OnSelected: func(id widget.ListItemID) {
-			setDay(days.Days[id])
+			setDay(shown[id])
 		},
 	}
+	statuses.onChange = list.Refresh
+
+	yearNames := make([]string, len(years))
+	for i, y := range years {
+		yearNames[i] = strconv.Itoa(y)
+	}
+	yearSelect := widget.NewSelect(yearNames, func(name string) {
+		year, _ := strconv.Atoi(name)
+		shown = days.InYear(year)
+		list.UnselectAll()
+		list.Refresh()
+	})
+	if len(years) > 0 {
+		yearSelect.SetSelected(yearNames[len(yearNames)-1])
+	}
 
-	return list
+	return container.NewBorder(yearSelect, nil, nil, nil, list)
 }
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"example.com/advent2022/days"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Form for trying a part's Params with other values than the defaults. Only
+// used for the puzzle (or custom) input, tests always use their own.
+type paramForm struct {
+	params  []*days.Param
+	entries []*widget.Entry
+}
+
+func newParamForm(params []*days.Param) *paramForm {
+	f := &paramForm{params: params, entries: make([]*widget.Entry, len(params))}
+	for i, param := range params {
+		entry := widget.NewEntry()
+		entry.SetText(strconv.FormatInt(param.Default, 10))
+		entry.Validator = func(s string) error {
+			_, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				return errors.New("expected a whole number")
+			}
+			return nil
+		}
+		f.entries[i] = entry
+	}
+	return f
+}
+
+// Collapsed by default since most of the time the defaults are what's wanted
+func (f *paramForm) widget() fyne.CanvasObject {
+	form := widget.NewForm()
+	for i, param := range f.params {
+		form.AppendItem(&widget.FormItem{
+			Text:     param.Name,
+			Widget:   f.entries[i],
+			HintText: param.Description + " (default " + strconv.FormatInt(param.Default, 10) + ")",
+		})
+	}
+	reset := widget.NewButton("Reset to defaults", func() {
+		for i, param := range f.params {
+			f.entries[i].SetText(strconv.FormatInt(param.Default, 10))
+		}
+	})
+	return widget.NewAccordion(widget.NewAccordionItem("Parameters", container.NewVBox(form, container.NewHBox(reset))))
+}
+
+// Values from the form. changed is set if any of them aren't the default, in
+// which case the solution can't be compared with the recorded answer.
+func (f *paramForm) values() (params days.Params, changed bool, err error) {
+	params = make(days.Params, len(f.params))
+	for i, param := range f.params {
+		v, err := strconv.ParseInt(strings.TrimSpace(f.entries[i].Text), 10, 64)
+		if err != nil {
+			return nil, false, errors.New("parameter " + param.Name + " isn't a whole number: " + f.entries[i].Text)
+		}
+		params[param] = v
+		if v != param.Default {
+			changed = true
+		}
+	}
+	return params, changed, nil
+}
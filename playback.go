@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"example.com/advent2022/days"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Speeds a simulation can be played at
+var playbackSpeeds = []struct {
+	name      string
+	perSecond float64
+}{
+	{"1 step/s", 1},
+	{"5 steps/s", 5},
+	{"20 steps/s", 20},
+	{"100 steps/s", 100},
+	{"1000 steps/s", 1000},
+}
+
+const defaultPlaybackSpeed = 1
+
+// Most frames drawn per second, faster speeds skip steps rather than drawing
+// every one
+const playbackFrameRate = 30
+
+// Steps through a PlaybackVisual with play/pause, step and seek controls. It
+// starts on the last frame so the final state is shown as for any other
+// visualization, and playing from there starts over.
+type playback struct {
+	vis days.PlaybackVisual
+	// Runs the day's code for drawing a frame, catching any panic
+	guard func(func()) error
+
+	mu      sync.Mutex
+	frame   int
+	stop    chan struct{} // closed to pause, nil while paused
+	speed   float64
+	pending float64 // steps owed by the ticker but not yet taken
+
+	view   *fyne.Container
+	label  *widget.Label
+	slider *widget.Slider
+	play   *widget.Button
+}
+
+func newPlayback(vis days.PlaybackVisual, guard func(func()) error) fyne.CanvasObject {
+	if vis.Frames == 0 {
+		return nil
+	}
+	p := &playback{
+		vis:   vis,
+		guard: guard,
+		speed: playbackSpeeds[defaultPlaybackSpeed].perSecond,
+		view:  container.NewMax(),
+		label: widget.NewLabel(""),
+	}
+	p.show(vis.Frames - 1)
+	if vis.Frames == 1 {
+		// Nothing to step through
+		return container.NewVBox(p.view, p.label)
+	}
+
+	p.slider = widget.NewSlider(0, float64(vis.Frames-1))
+	p.slider.Value = float64(vis.Frames - 1)
+	p.slider.OnChanged = func(v float64) {
+		p.mu.Lock()
+		same := int(v) == p.frame
+		p.mu.Unlock()
+		if !same {
+			p.show(int(v))
+		}
+	}
+	p.play = widget.NewButtonWithIcon("", theme.MediaPlayIcon(), p.togglePlaying)
+
+	speedNames := make([]string, len(playbackSpeeds))
+	for i, s := range playbackSpeeds {
+		speedNames[i] = s.name
+	}
+	speed := widget.NewSelect(speedNames, func(name string) {
+		for _, s := range playbackSpeeds {
+			if s.name == name {
+				p.mu.Lock()
+				p.speed = s.perSecond
+				p.mu.Unlock()
+			}
+		}
+	})
+	speed.SetSelected(playbackSpeeds[defaultPlaybackSpeed].name)
+
+	controls := container.NewHBox(
+		widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() { p.pause(); p.show(0) }),
+		widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() { p.pause(); p.step(-1) }),
+		p.play,
+		widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() { p.pause(); p.step(1) }),
+		widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), func() { p.pause(); p.show(vis.Frames - 1) }),
+		speed,
+	)
+	return container.NewVBox(p.view, p.label, container.NewBorder(nil, nil, controls, nil, p.slider))
+}
+
+// Draws frame i (clamped to the frames there are) and moves the slider to it
+func (p *playback) show(i int) {
+	if i < 0 {
+		i = 0
+	}
+	if i >= p.vis.Frames {
+		i = p.vis.Frames - 1
+	}
+	p.mu.Lock()
+	p.frame = i
+	p.mu.Unlock()
+
+	var obj fyne.CanvasObject
+	var err error
+	text := fmt.Sprintf("Step %d of %d", i, p.vis.Frames-1)
+	if panicErr := p.guard(func() {
+		obj, err = renderVisual(p.vis.Frame(i), p.guard)
+		if p.vis.Label != nil {
+			text += ": " + p.vis.Label(i)
+		}
+	}); panicErr != nil {
+		err = panicErr
+	}
+	if err != nil {
+		box := container.NewVBox()
+		addVisualError(box, fmt.Sprint("Failed to draw step ", i, ": ", err), err)
+		obj = box
+	}
+	if obj == nil {
+		p.view.Objects = nil
+	} else {
+		p.view.Objects = []fyne.CanvasObject{obj}
+	}
+	p.view.Refresh()
+
+	p.label.SetText(text)
+	if p.slider != nil {
+		p.slider.SetValue(float64(i))
+	}
+}
+
+func (p *playback) step(by int) {
+	p.mu.Lock()
+	i := p.frame + by
+	p.mu.Unlock()
+	p.show(i)
+}
+
+func (p *playback) togglePlaying() {
+	p.mu.Lock()
+	playing := p.stop != nil
+	p.mu.Unlock()
+	if playing {
+		p.pause()
+	} else {
+		p.start()
+	}
+}
+
+func (p *playback) start() {
+	p.mu.Lock()
+	if p.stop != nil {
+		p.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	p.stop = stop
+	p.pending = 0
+	atEnd := p.frame == p.vis.Frames-1
+	p.mu.Unlock()
+
+	p.play.SetIcon(theme.MediaPauseIcon())
+	if atEnd {
+		p.show(0)
+	}
+	go func() {
+		ticker := time.NewTicker(time.Second / playbackFrameRate)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			p.mu.Lock()
+			p.pending += p.speed / playbackFrameRate
+			steps := int(p.pending)
+			p.pending -= float64(steps)
+			next := p.frame + steps
+			p.mu.Unlock()
+			if steps == 0 {
+				continue
+			}
+			p.show(next)
+			if next >= p.vis.Frames-1 {
+				p.finish(stop)
+				return
+			}
+		}
+	}()
+}
+
+func (p *playback) pause() {
+	p.mu.Lock()
+	stop := p.stop
+	p.mu.Unlock()
+	p.finish(stop)
+}
+
+// Stops the run of playing started with stop, unless it's already been stopped
+func (p *playback) finish(stop chan struct{}) {
+	p.mu.Lock()
+	if stop == nil || p.stop != stop {
+		p.mu.Unlock()
+		return
+	}
+	p.stop = nil
+	p.mu.Unlock()
+	close(stop)
+	p.play.SetIcon(theme.MediaPlayIcon())
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"example.com/advent2022/days"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Solvers can report progress far more often than it's worth redrawing, so
+// this drops updates that arrive less than interval after the last one
+func throttleProgress(report days.ProgressFunc, interval time.Duration) days.ProgressFunc {
+	var last time.Time
+	return func(p days.Progress) {
+		now := time.Now()
+		if now.Sub(last) < interval {
+			return
+		}
+		last = now
+		report(p)
+	}
+}
+
+func formatCounters(counters []days.Counter) string {
+	parts := make([]string, len(counters))
+	for i, c := range counters {
+		parts[i] = fmt.Sprint(c.Name, ": ", c.Value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Progress bar plus the latest counters for a running solve. The bar turns
+// into an infinite one when the solver can't tell how far along it is.
+type progressView struct {
+	bar      *widget.ProgressBar
+	infinite *widget.ProgressBarInfinite
+	counters *widget.Label
+	box      *fyne.Container
+}
+
+func newProgressView() *progressView {
+	v := &progressView{
+		bar:      widget.NewProgressBar(),
+		infinite: widget.NewProgressBarInfinite(),
+		counters: widget.NewLabel(""),
+	}
+	v.box = container.NewVBox(v.bar, v.infinite, v.counters)
+	v.box.Hide()
+	return v
+}
+
+func (v *progressView) start() {
+	v.bar.SetValue(0)
+	v.bar.Show()
+	v.infinite.Hide()
+	v.counters.SetText("")
+	v.box.Show()
+}
+
+func (v *progressView) update(p days.Progress) {
+	if p.Fraction < 0 {
+		v.bar.Hide()
+		v.infinite.Show()
+	} else {
+		v.infinite.Hide()
+		v.bar.Show()
+		v.bar.SetValue(p.Fraction)
+	}
+	v.counters.SetText(formatCounters(p.Counters))
+}
+
+func (v *progressView) finish() {
+	v.infinite.Stop()
+	v.box.Hide()
+}
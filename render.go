@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"example.com/advent2022/days"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"gonum.org/v1/plot"
+)
+
+// Turns a solver's Visual into something that can be added to the window.
+// Returns nil if there's nothing to show. Playback frames are drawn later, as
+// they're stepped to, through guard (see days.Day.Guard).
+func renderVisual(v days.Visual, guard func(func()) error) (fyne.CanvasObject, error) {
+	switch vis := v.(type) {
+	case nil:
+		return nil, nil
+	case days.PlotVisual:
+		return plotToImage(vis.Plot, vis.Name)
+	case days.TextVisual:
+		label := widget.NewLabel(vis.Text)
+		if !vis.Monospace {
+			return label, nil
+		}
+		label.TextStyle.Monospace = true
+		return container.NewHScroll(label), nil
+	case days.TreeVisual:
+		// TODO: make tree view bigger, probably requires changing the layout in the main app :(
+		return widget.NewTreeWithStrings(vis.Children), nil
+	case days.GridVisual:
+		return renderGrid(vis), nil
+	case days.HighlightVisual:
+		t0 := canvas.NewText(vis.Text[:vis.Start], color.Black)
+		t1 := canvas.NewText(vis.Text[vis.Start:vis.End], color.RGBA{255, 0, 0, 255})
+		t2 := canvas.NewText(vis.Text[vis.End:], color.Black)
+		return container.NewHScroll(container.NewHBox(t0, t1, t2)), nil
+	case days.PlaybackVisual:
+		return newPlayback(vis, guard), nil
+	default:
+		return nil, fmt.Errorf("don't know how to draw a %T", v)
+	}
+}
+
+func plotToImage(sol_plt *plot.Plot, imageName string) (*canvas.Image, error) {
+	w, writer_err := sol_plt.WriterTo(plotWidth, plotHeight, "png")
+	if writer_err != nil {
+		failMsg := fmt.Sprint("Failed to save plot, err: ", writer_err)
+		return nil, errors.New(failMsg)
+	}
+	b := bytes.Buffer{}
+	if _, err := w.WriteTo(&b); err != nil {
+		failMsg := fmt.Sprint("Failed to save plot, err: ", err)
+		return nil, errors.New(failMsg)
+	}
+	img := canvas.NewImageFromReader(&b, imageName)
+	img.FillMode = canvas.ImageFillOriginal
+	return img, nil
+}
+
+func renderGrid(vis days.GridVisual) fyne.CanvasObject {
+	if len(vis.Cells) == 0 {
+		return container.NewHScroll(container.NewHBox())
+	}
+	grid := container.New(layout.NewGridLayout(len(vis.Cells[0])))
+	for y := range vis.Cells {
+		for x := range vis.Cells[y] {
+			if vis.Highlight[y][x] {
+				grid.Add(canvas.NewText(vis.Cells[y][x], color.RGBA{255, 0, 0, 255}))
+			} else {
+				grid.Add(canvas.NewText(vis.Cells[y][x], color.Black))
+			}
+		}
+	}
+	return container.NewHScroll(grid)
+}
+
+// Lines of input shown either side of the one being pointed at
+const inputContextLines = 2
+
+// Longest stretch of a line shown, some inputs are one very long line
+const maxInputLineWidth = 120
+
+// Shows the input around line (1 based) with that line highlighted and a
+// caret under column (1 based, 0 for none)
+func renderInputLine(input string, line, column int) fyne.CanvasObject {
+	lines := strings.Split(input, "\n")
+	box := container.NewVBox()
+	if line < 1 || line > len(lines) {
+		return box
+	}
+	numWidth := len(strconv.Itoa(line + inputContextLines))
+	newLine := func(text string, c color.Color) {
+		t := canvas.NewText(text, c)
+		t.TextStyle.Monospace = true
+		box.Add(t)
+	}
+	for i := line - inputContextLines; i <= line+inputContextLines; i++ {
+		if i < 1 || i > len(lines) {
+			continue
+		}
+		text, offset := clipAround(lines[i-1], column)
+		prefix := fmt.Sprintf("%*d | ", numWidth, i)
+		if i != line {
+			newLine(prefix+text, color.Gray{Y: 128})
+			continue
+		}
+		newLine(prefix+text, color.RGBA{255, 0, 0, 255})
+		if column > 0 {
+			newLine(strings.Repeat(" ", len(prefix)+column-1-offset)+"^", color.RGBA{255, 0, 0, 255})
+		}
+	}
+	return container.NewHScroll(box)
+}
+
+// Cuts long lines down to maxInputLineWidth around column, returns the part
+// to show and how many characters were cut from the start
+func clipAround(text string, column int) (string, int) {
+	if len(text) <= maxInputLineWidth {
+		return text, 0
+	}
+	start := column - maxInputLineWidth/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxInputLineWidth
+	if end > len(text) {
+		end = len(text)
+		start = end - maxInputLineWidth
+	}
+	return text[start:end], start
+}
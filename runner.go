@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"example.com/advent2022/days"
+)
+
+// Loads the recorded answers in dir, warning on stderr about years without an
+// answers file so solutions that can't be checked aren't mistaken for passing
+func loadAnswers(dir string) (days.Answers, error) {
+	answers, missing, err := days.LoadAnswers(dir)
+	for _, year := range missing {
+		fmt.Fprintf(os.Stderr, "Warning: no recorded answers for %d at %s, its puzzle solutions won't be checked\n", year, days.AnswersPath(dir, year))
+	}
+	return answers, err
+}
+
+type testResult struct {
+	index    int
+	test     days.SinglePartTest
+	result   days.Result
+	err      error
+	duration time.Duration
+}
+
+func (r testResult) passed() bool {
+	return r.err == nil && r.result.Answer == r.test.ExpectedOutput
+}
+
+// Message describing why the test failed, or an empty string if it passed
+func (r testResult) failure(d days.Day, p days.Part) string {
+	if r.err != nil {
+		return fmt.Sprint("Day ", d.Number, " part ", p, ": test ", r.index, " returned err: ", r.err.Error())
+	}
+	if r.result.Answer != r.test.ExpectedOutput {
+		return fmt.Sprint("Day ", d.Number, " part ", p, ": test ", r.index, " failed. Got: ", r.result.Answer, ", expected: ", r.test.ExpectedOutput)
+	}
+	return ""
+}
+
+// Runs every SinglePartTest for the part, in order. Once ctx is cancelled the
+// remaining tests fail straight away with the cancellation error.
+func runTests(ctx context.Context, d days.Day, p days.Part) []testResult {
+	results := make([]testResult, len(d.Tests(p)))
+	for i := range results {
+		results[i] = runTest(ctx, d, p, i)
+	}
+	return results
+}
+
+// Runs the part's i'th SinglePartTest with its own params
+func runTest(ctx context.Context, d days.Day, p days.Part, i int) testResult {
+	r := testResult{index: i, test: d.Tests(p)[i]}
+	start := time.Now()
+	r.result, r.err = d.Solve(ctx, p, r.test.Input, r.test.Params)
+	r.duration = time.Since(start)
+	return r
+}
+
+// Stack trace of the panic behind err, empty if it wasn't one
+func panicStack(err error) string {
+	var panicErr *days.PanicError
+	if errors.As(err, &panicErr) {
+		return string(panicErr.Stack)
+	}
+	return ""
+}
+
+func allPassed(results []testResult) bool {
+	for _, r := range results {
+		if !r.passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// How much a solve allocated. Allocations are counted process wide, so
+// anything else running at the same time (like the GUI) is included too.
+type allocStats struct {
+	allocs uint64
+	bytes  uint64
+}
+
+func (s allocStats) String() string {
+	return fmt.Sprintf("%d allocations (%s)", s.allocs, formatBytes(s.bytes))
+}
+
+// Solves the input with params while counting allocations
+func measureSolve(ctx context.Context, d days.Day, p days.Part, input string, params days.Params) (days.Result, allocStats, error) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	res, err := d.Solve(ctx, p, input, params)
+	runtime.ReadMemStats(&after)
+	stats := allocStats{
+		allocs: after.Mallocs - before.Mallocs,
+		bytes:  after.TotalAlloc - before.TotalAlloc,
+	}
+	return res, stats, err
+}
+
+// Total time followed by any phases the solver timed itself,
+// ex: "1.5s (Build graph: 2ms, Shortest paths: 1.498s)"
+func formatTimings(res days.Result) string {
+	total := res.Timing(days.TotalTiming).Round(time.Microsecond).String()
+	phases := make([]string, 0, len(res.Timings))
+	for _, t := range res.Timings {
+		if t.Name != days.TotalTiming {
+			phases = append(phases, t.Name+": "+t.Duration.Round(time.Microsecond).String())
+		}
+	}
+	if len(phases) == 0 {
+		return total
+	}
+	return total + " (" + strings.Join(phases, ", ") + ")"
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
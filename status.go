@@ -0,0 +1,91 @@
+package main
+
+import (
+	"sync"
+
+	"example.com/advent2022/days"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// How far along a part is, shown next to each day in the nav list
+type partStatus int
+
+const (
+	statusNotRun partStatus = iota
+	statusNotImplemented
+	statusFailing
+	statusSolved
+	// Solved and matches the recorded answer
+	statusVerified
+)
+
+func (s partStatus) icon() fyne.Resource {
+	switch s {
+	case statusNotImplemented:
+		return theme.ContentRemoveIcon()
+	case statusFailing:
+		return theme.NewErrorThemedResource(theme.ErrorIcon())
+	case statusSolved:
+		return theme.ConfirmIcon()
+	case statusVerified:
+		return theme.NewPrimaryThemedResource(theme.CheckButtonCheckedIcon())
+	default:
+		return theme.RadioButtonIcon()
+	}
+}
+
+// Statuses of every day's parts for the session. Parts start out as either
+// not implemented or not run, and are updated as they're solved.
+type statusBoard struct {
+	mu       sync.Mutex
+	parts    map[days.Key]*[2]partStatus
+	onChange func()
+}
+
+func newStatusBoard() *statusBoard {
+	b := &statusBoard{parts: make(map[days.Key]*[2]partStatus)}
+	for _, d := range days.All() {
+		statuses := &[2]partStatus{}
+		for i, p := range []days.Part{days.PartA, days.PartB} {
+			if !d.Implemented(p) {
+				statuses[i] = statusNotImplemented
+			}
+		}
+		b.parts[d.Key()] = statuses
+	}
+	return b
+}
+
+func (b *statusBoard) set(d days.Day, p days.Part, s partStatus) {
+	b.mu.Lock()
+	b.parts[d.Key()][p] = s
+	onChange := b.onChange
+	b.mu.Unlock()
+	if onChange != nil {
+		onChange()
+	}
+}
+
+// Status of the day as a whole: failing if any part is, otherwise only as far
+// along as its least finished part. Parts that aren't implemented are left out
+// unless none of them are.
+func (b *statusBoard) day(d days.Day) partStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	statuses, ok := b.parts[d.Key()]
+	if !ok {
+		return statusNotImplemented
+	}
+	day := statusNotImplemented
+	for _, s := range statuses {
+		switch {
+		case s == statusNotImplemented:
+		case s == statusFailing || day == statusFailing:
+			day = statusFailing
+		case day == statusNotImplemented || s < day:
+			day = s
+		}
+	}
+	return day
+}
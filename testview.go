@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"example.com/advent2022/days"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Columns of the test table, the first row holds their names
+var testColumns = []struct {
+	name  string
+	width float32
+}{
+	{"Test", 50},
+	{"Status", 80},
+	{"Expected", 220},
+	{"Actual", 220},
+	{"Duration", 100},
+}
+
+// Rows shown before the table scrolls
+const maxTestRowsShown = 8
+
+// Table of a part's tests that fills in as they run. Selecting a row shows the
+// test's input, how its answer differs from the expected one and its
+// visualization.
+type testTable struct {
+	day  days.Day
+	part days.Part
+
+	mu      sync.Mutex
+	results []*testResult // nil until the test has finished
+	running int           // index of the test being run, -1 for none
+
+	table  *widget.Table
+	detail *fyne.Container
+	box    *fyne.Container
+	window fyne.Window
+}
+
+func newTestTable(d days.Day, p days.Part, w fyne.Window) *testTable {
+	t := &testTable{
+		day:     d,
+		part:    p,
+		window:  w,
+		results: make([]*testResult, len(d.Tests(p))),
+		running: -1,
+		detail:  container.NewVBox(),
+	}
+	t.table = widget.NewTable(
+		func() (int, int) {
+			return len(t.results) + 1, len(testColumns)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, cell fyne.CanvasObject) {
+			label := cell.(*widget.Label)
+			label.TextStyle.Bold = id.Row == 0
+			label.SetText(t.cellText(id))
+		},
+	)
+	var width float32
+	for i, c := range testColumns {
+		t.table.SetColumnWidth(i, c.width)
+		width += c.width
+	}
+	t.table.OnSelected = func(id widget.TableCellID) {
+		if id.Row == 0 {
+			// Header
+			t.table.Unselect(id)
+			return
+		}
+		t.showDetail(id.Row - 1)
+	}
+
+	rows := len(t.results) + 1
+	if rows > maxTestRowsShown+1 {
+		rows = maxTestRowsShown + 1
+	}
+	rowHeight := widget.NewLabel("").MinSize().Height
+	sized := container.New(layout.NewGridWrapLayout(fyne.NewSize(width, float32(rows)*rowHeight)), t.table)
+	t.box = container.NewVBox(sized, t.detail)
+	return t
+}
+
+func (t *testTable) cellText(id widget.TableCellID) string {
+	if id.Row == 0 {
+		return testColumns[id.Col].name
+	}
+	i := id.Row - 1
+	t.mu.Lock()
+	r := t.results[i]
+	running := t.running == i
+	t.mu.Unlock()
+
+	switch id.Col {
+	case 0:
+		return strconv.Itoa(i)
+	case 1:
+		switch {
+		case running:
+			return "running"
+		case r == nil:
+			return "pending"
+		case r.passed():
+			return "passed"
+		case r.err != nil:
+			return "error"
+		default:
+			return "failed"
+		}
+	case 2:
+		return firstLine(t.day.Tests(t.part)[i].ExpectedOutput)
+	case 3:
+		if r == nil || r.err != nil {
+			return ""
+		}
+		return firstLine(r.result.Answer)
+	default:
+		if r == nil {
+			return ""
+		}
+		return r.duration.Round(time.Microsecond).String()
+	}
+}
+
+// Multi-line answers only get their first line in the table, the rest is
+// shown in the diff
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + " …"
+	}
+	return s
+}
+
+func (t *testTable) start(i int) {
+	t.mu.Lock()
+	t.running = i
+	t.mu.Unlock()
+	t.table.Refresh()
+}
+
+func (t *testTable) finish(r testResult) {
+	t.mu.Lock()
+	t.results[r.index] = &r
+	t.running = -1
+	t.mu.Unlock()
+	t.table.Refresh()
+}
+
+// Picks the first failing test to show, or the first test if they all passed
+func (t *testTable) selectFirstFailure() {
+	t.mu.Lock()
+	row := 0
+	for i, r := range t.results {
+		if r != nil && !r.passed() {
+			row = i
+			break
+		}
+	}
+	t.mu.Unlock()
+	if len(t.results) > 0 {
+		t.table.Select(widget.TableCellID{Row: row + 1, Col: 0})
+	}
+}
+
+func (t *testTable) showDetail(i int) {
+	t.mu.Lock()
+	r := t.results[i]
+	t.mu.Unlock()
+	test := t.day.Tests(t.part)[i]
+
+	t.detail.Objects = nil
+	t.detail.Add(widget.NewLabel(fmt.Sprint("Test ", i, " input:")))
+	input := widget.NewLabel(test.Input)
+	input.TextStyle.Monospace = true
+	scroll := container.NewScroll(input)
+	scroll.SetMinSize(fyne.NewSize(0, 150))
+	t.detail.Add(scroll)
+	if len(test.Params) > 0 {
+		t.detail.Add(widget.NewLabel("Parameters: " + formatParams(test.Params)))
+	}
+
+	switch {
+	case r == nil:
+		t.detail.Add(widget.NewLabel("Not run yet"))
+	case r.err != nil:
+		addSolveError(t.detail, r.failure(t.day, t.part), fmt.Sprint("Test ", i), test.Input, r.err)
+	case !r.passed():
+		addAnswerDiff(t.detail, test.ExpectedOutput, r.result.Answer)
+	}
+	if r != nil && r.err == nil {
+		addVisual(t.detail, t.day, t.part, r.result.Visual, t.window)
+	}
+	t.detail.Refresh()
+}
+
+// Shows expected above actual with the differences highlighted. Answers that
+// span several lines (pictures) are compared cell by cell instead.
+func addAnswerDiff(vbox *fyne.Container, expected, actual string) {
+	if strings.Contains(expected, "\n") || strings.Contains(actual, "\n") {
+		exp, act := diffGrid(expected, actual)
+		vbox.Add(widget.NewLabel("Expected:"))
+		vbox.Add(renderGrid(exp))
+		vbox.Add(widget.NewLabel("Actual:"))
+		vbox.Add(renderGrid(act))
+		return
+	}
+	vbox.Add(widget.NewLabel("Expected (top) and actual (bottom):"))
+	vbox.Add(renderGrid(diffCharGrid(expected, actual)))
+}
+
+func formatParams(params days.Params) string {
+	parts := make([]string, 0, len(params))
+	for param, v := range params {
+		parts = append(parts, param.Name+" = "+strconv.FormatInt(v, 10))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}